@@ -0,0 +1,28 @@
+package concerns
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+var isolationLevels = map[string]sql.IsolationLevel{
+	"":                 sql.LevelDefault,
+	"read uncommitted": sql.LevelReadUncommitted,
+	"read committed":   sql.LevelReadCommitted,
+	"repeatable read":  sql.LevelRepeatableRead,
+	"snapshot":         sql.LevelSnapshot,
+	"serializable":     sql.LevelSerializable,
+}
+
+func ParseIsolationLevel(name string) (sql.IsolationLevel, error) {
+
+	name = strings.ToLower(strings.Join(strings.Fields(strings.ReplaceAll(name, "_", " ")), " "))
+
+	level, ok := isolationLevels[name]
+	if !ok {
+		return sql.LevelDefault, errors.New("Unsupported transaction isolation level " + name)
+	}
+
+	return level, nil
+}
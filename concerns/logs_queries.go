@@ -0,0 +1,100 @@
+package concerns
+
+import (
+	"database/contracts"
+	"sync"
+	"time"
+)
+
+type slowQueryHandler struct {
+	threshold time.Duration
+	callback  func(event contracts.QueryEvent)
+}
+
+type LogsQueries struct {
+	mutex             sync.RWMutex
+	listeners         []contracts.QueryListener
+	slowQueryHandlers []slowQueryHandler
+	loggingQueries    bool
+	queryLog          []contracts.QueryEvent
+}
+
+func NewLogsQueries() *LogsQueries {
+
+	return &LogsQueries{}
+}
+
+func (lq *LogsQueries) Listen(listener contracts.QueryListener) {
+
+	lq.mutex.Lock()
+	defer lq.mutex.Unlock()
+
+	lq.listeners = append(lq.listeners, listener)
+}
+
+func (lq *LogsQueries) EnableQueryLog() {
+
+	lq.mutex.Lock()
+	defer lq.mutex.Unlock()
+
+	lq.loggingQueries = true
+}
+
+func (lq *LogsQueries) DisableQueryLog() {
+
+	lq.mutex.Lock()
+	defer lq.mutex.Unlock()
+
+	lq.loggingQueries = false
+}
+
+func (lq *LogsQueries) GetQueryLog() []contracts.QueryEvent {
+
+	lq.mutex.RLock()
+	defer lq.mutex.RUnlock()
+
+	return append([]contracts.QueryEvent{}, lq.queryLog...)
+}
+
+func (lq *LogsQueries) FlushQueryLog() {
+
+	lq.mutex.Lock()
+	defer lq.mutex.Unlock()
+
+	lq.queryLog = nil
+}
+
+func (lq *LogsQueries) WhenQueryingForLongerThan(threshold time.Duration, callback func(event contracts.QueryEvent)) {
+
+	lq.mutex.Lock()
+	defer lq.mutex.Unlock()
+
+	lq.slowQueryHandlers = append(lq.slowQueryHandlers, slowQueryHandler{
+		threshold: threshold,
+		callback:  callback,
+	})
+}
+
+func (lq *LogsQueries) LogQuery(event contracts.QueryEvent) {
+
+	lq.mutex.Lock()
+
+	if lq.loggingQueries {
+		lq.queryLog = append(lq.queryLog, event)
+	}
+
+	listeners := lq.listeners
+	handlers := lq.slowQueryHandlers
+
+	lq.mutex.Unlock()
+
+	for _, listener := range listeners {
+		listener.Handle(event)
+	}
+
+	for _, handler := range handlers {
+		if event.Time >= handler.threshold {
+			handler.callback(event)
+		}
+	}
+}
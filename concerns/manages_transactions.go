@@ -1,16 +1,36 @@
 package concerns
 
 import (
+	"context"
 	"database/contracts"
 	"database/sql"
+	"errors"
 	"fmt"
 )
 
+type transactionListener struct {
+	level    int
+	listener func(level int)
+}
+
+type afterCommitCallback struct {
+	level    int
+	callback func()
+}
+
 type ManagesTransactions struct {
 	pdo          *sql.DB
+	pdoResolver  func() (*sql.DB, error)
 	tx           *sql.Tx
 	grammar      contracts.Grammar
 	transactions int
+	isolation    sql.IsolationLevel
+
+	connectionListeners *TransactionListeners
+	beginListeners      []transactionListener
+	commitListeners     []transactionListener
+	rollbackListeners   []transactionListener
+	afterCommit         []afterCommitCallback
 }
 
 func NewManagesTransactions(pdo *sql.DB, grammar contracts.Grammar) *ManagesTransactions {
@@ -22,41 +42,147 @@ func NewManagesTransactions(pdo *sql.DB, grammar contracts.Grammar) *ManagesTran
 	}
 }
 
-func (mt *ManagesTransactions) BeginTransaction() {
+func (mt *ManagesTransactions) SetPDOResolver(resolver func() (*sql.DB, error)) {
+
+	mt.pdoResolver = resolver
+}
+
+func (mt *ManagesTransactions) SetConnectionListeners(listeners *TransactionListeners) {
+
+	mt.connectionListeners = listeners
+}
 
-	mt.createTransaction()
+func (mt *ManagesTransactions) SetDefaultIsolation(level sql.IsolationLevel) {
+
+	mt.isolation = level
+}
+
+func (mt *ManagesTransactions) BeginTransaction() error {
+
+	return mt.BeginTransactionContext(context.Background(), nil)
+}
+
+func (mt *ManagesTransactions) BeginTransactionContext(ctx context.Context, opts *sql.TxOptions) error {
+
+	if err := mt.createTransaction(ctx, opts); err != nil {
+		return err
+	}
 
 	mt.transactions++
+
+	if mt.connectionListeners != nil {
+		mt.connectionListeners.FireBegin(mt.transactions)
+	}
+
+	mt.fireTransactionEvent(mt.beginListeners)
+
+	return nil
 }
 
-func (mt *ManagesTransactions) createTransaction() {
+func (mt *ManagesTransactions) createTransaction(ctx context.Context, opts *sql.TxOptions) error {
 
 	if mt.TransactionLevel() == 0 {
-		tx, err := mt.pdo.Begin()
-		prepareError(err)
+		if opts == nil && mt.isolation != sql.LevelDefault {
+			opts = &sql.TxOptions{Isolation: mt.isolation}
+		}
+
+		if opts != nil && opts.ReadOnly && !mt.grammar.SupportsReadOnlyTransactions() {
+			return errors.New("This database engine does not support read-only transactions")
+		}
+
+		if opts != nil && !mt.grammar.SupportsTransactionOptions() {
+			return mt.createTransactionWithStatement(ctx, opts)
+		}
+
+		pdo, err := mt.getPdo()
+		if err != nil {
+			return err
+		}
+
+		tx, err := pdo.BeginTx(ctx, opts)
+		if err != nil {
+			return err
+		}
 
 		mt.tx = tx
 	} else {
-		_, err := mt.tx.Exec(mt.grammar.CompileSavepoint(
-			mt.formatTxName(mt.TransactionLevel() + 1),
+		_, err := mt.tx.ExecContext(ctx, mt.grammar.CompileSavepoint(
+			mt.formatTxName(mt.TransactionLevel()+1),
 		))
-		prepareError(err)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (mt *ManagesTransactions) createTransactionWithStatement(ctx context.Context, opts *sql.TxOptions) error {
+
+	pdo, err := mt.getPdo()
+	if err != nil {
+		return err
+	}
+
+	tx, err := pdo.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if statement := mt.grammar.CompileSetTransaction(opts); len(statement) > 0 {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			tx.Rollback()
+			return err
+		}
 	}
+
+	mt.tx = tx
+
+	return nil
 }
 
-func (mt *ManagesTransactions) Commit() {
+func (mt *ManagesTransactions) getPdo() (*sql.DB, error) {
+
+	if mt.pdoResolver != nil {
+		return mt.pdoResolver()
+	}
+
+	return mt.pdo, nil
+}
+
+func (mt *ManagesTransactions) Commit() error {
 
 	if mt.TransactionLevel() == 1 {
-		err := mt.tx.Commit()
-		prepareError(err)
+		if err := mt.tx.Commit(); err != nil {
+			mt.transactions = 0
+			mt.afterCommit = nil
+			mt.discardTransactionListeners(0)
+			return err
+		}
 	}
 
 	if mt.transactions--; mt.transactions < 0 {
 		mt.transactions = 0
 	}
+
+	if mt.connectionListeners != nil {
+		mt.connectionListeners.FireCommit(mt.transactions)
+	}
+
+	mt.fireTransactionEvent(mt.commitListeners)
+
+	if mt.transactions == 0 {
+		mt.discardTransactionListeners(0)
+		mt.runAfterCommitCallbacks()
+	} else {
+		mt.moveTransactionListeners(mt.transactions)
+		mt.moveAfterCommitCallbacks(mt.transactions)
+	}
+
+	return nil
 }
 
-func (mt *ManagesTransactions) RollBack(level interface{}) {
+func (mt *ManagesTransactions) RollBack(level interface{}) error {
 
 	toLevel := 0
 	if level != nil {
@@ -66,20 +192,133 @@ func (mt *ManagesTransactions) RollBack(level interface{}) {
 	}
 
 	if toLevel < 0 || toLevel >= mt.transactions {
-		return
+		return nil
 	}
 
 	if toLevel == 0 {
 		err := mt.tx.Rollback()
-		prepareError(err)
+		mt.transactions = 0
+		mt.afterCommit = nil
+
+		if err != nil {
+			mt.discardTransactionListeners(0)
+			return err
+		}
 	} else {
 		_, err := mt.tx.Exec(mt.grammar.CompileSavepointRollback(
 			mt.formatTxName(toLevel + 1),
 		))
-		prepareError(err)
+		if err != nil {
+			return err
+		}
 	}
 
 	mt.transactions = toLevel
+	mt.discardAfterCommitCallbacks(toLevel)
+
+	if mt.connectionListeners != nil {
+		mt.connectionListeners.FireRollback(mt.transactions)
+	}
+
+	mt.fireTransactionEvent(mt.rollbackListeners)
+	mt.discardTransactionListeners(toLevel)
+
+	return nil
+}
+
+func (mt *ManagesTransactions) OnBegin(listener func(level int)) {
+
+	mt.beginListeners = append(mt.beginListeners, transactionListener{mt.transactions, listener})
+}
+
+func (mt *ManagesTransactions) OnCommit(listener func(level int)) {
+
+	mt.commitListeners = append(mt.commitListeners, transactionListener{mt.transactions, listener})
+}
+
+func (mt *ManagesTransactions) OnRollback(listener func(level int)) {
+
+	mt.rollbackListeners = append(mt.rollbackListeners, transactionListener{mt.transactions, listener})
+}
+
+func (mt *ManagesTransactions) AfterCommit(callback func()) {
+
+	if mt.TransactionLevel() == 0 {
+		callback()
+		return
+	}
+
+	mt.afterCommit = append(mt.afterCommit, afterCommitCallback{
+		level:    mt.TransactionLevel(),
+		callback: callback,
+	})
+}
+
+func (mt *ManagesTransactions) fireTransactionEvent(listeners []transactionListener) {
+
+	for _, l := range listeners {
+		l.listener(mt.transactions)
+	}
+}
+
+func (mt *ManagesTransactions) moveTransactionListeners(level int) {
+
+	for _, listeners := range [][]transactionListener{mt.beginListeners, mt.commitListeners, mt.rollbackListeners} {
+		for i := range listeners {
+			if listeners[i].level > level {
+				listeners[i].level = level
+			}
+		}
+	}
+}
+
+func (mt *ManagesTransactions) discardTransactionListeners(level int) {
+
+	keep := func(listeners []transactionListener) []transactionListener {
+		var res []transactionListener
+		for _, l := range listeners {
+			if l.level <= level {
+				res = append(res, l)
+			}
+		}
+
+		return res
+	}
+
+	mt.beginListeners = keep(mt.beginListeners)
+	mt.commitListeners = keep(mt.commitListeners)
+	mt.rollbackListeners = keep(mt.rollbackListeners)
+}
+
+func (mt *ManagesTransactions) runAfterCommitCallbacks() {
+
+	callbacks := mt.afterCommit
+	mt.afterCommit = nil
+
+	for _, c := range callbacks {
+		c.callback()
+	}
+}
+
+func (mt *ManagesTransactions) moveAfterCommitCallbacks(level int) {
+
+	for i := range mt.afterCommit {
+		if mt.afterCommit[i].level > level {
+			mt.afterCommit[i].level = level
+		}
+	}
+}
+
+func (mt *ManagesTransactions) discardAfterCommitCallbacks(level int) {
+
+	var callbacks []afterCommitCallback
+	for _, c := range mt.afterCommit {
+		if c.level <= level {
+			callbacks = append(callbacks, c)
+		}
+	}
+
+	mt.afterCommit = callbacks
 }
 
 func (mt *ManagesTransactions) TransactionLevel() int {
@@ -96,10 +335,3 @@ func (mt *ManagesTransactions) formatTxName(cnt int) string {
 
 	return fmt.Sprintf("trans%d", cnt)
 }
-
-func prepareError(err error) {
-
-	if err != nil {
-		panic(err)
-	}
-}
@@ -0,0 +1,65 @@
+package concerns
+
+import "sync"
+
+type TransactionListeners struct {
+	mutex             sync.RWMutex
+	beginListeners    []func(level int)
+	commitListeners   []func(level int)
+	rollbackListeners []func(level int)
+}
+
+func NewTransactionListeners() *TransactionListeners {
+
+	return &TransactionListeners{}
+}
+
+func (tl *TransactionListeners) OnBegin(listener func(level int)) {
+
+	tl.mutex.Lock()
+	defer tl.mutex.Unlock()
+
+	tl.beginListeners = append(tl.beginListeners, listener)
+}
+
+func (tl *TransactionListeners) OnCommit(listener func(level int)) {
+
+	tl.mutex.Lock()
+	defer tl.mutex.Unlock()
+
+	tl.commitListeners = append(tl.commitListeners, listener)
+}
+
+func (tl *TransactionListeners) OnRollback(listener func(level int)) {
+
+	tl.mutex.Lock()
+	defer tl.mutex.Unlock()
+
+	tl.rollbackListeners = append(tl.rollbackListeners, listener)
+}
+
+func (tl *TransactionListeners) FireBegin(level int) {
+
+	tl.fire(&tl.beginListeners, level)
+}
+
+func (tl *TransactionListeners) FireCommit(level int) {
+
+	tl.fire(&tl.commitListeners, level)
+}
+
+func (tl *TransactionListeners) FireRollback(level int) {
+
+	tl.fire(&tl.rollbackListeners, level)
+}
+
+func (tl *TransactionListeners) fire(listeners *[]func(level int), level int) {
+
+	tl.mutex.RLock()
+	snapshot := append([]func(level int){}, *listeners...)
+	tl.mutex.RUnlock()
+
+	for _, listener := range snapshot {
+		listener(level)
+	}
+}
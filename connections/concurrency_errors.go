@@ -0,0 +1,44 @@
+package connections
+
+import (
+	"errors"
+	"github.com/go-sql-driver/mysql"
+	"strings"
+)
+
+var concurrencyErrorCodes = map[uint16]bool{
+	1205: true,
+	1213: true,
+}
+
+var concurrencyErrorMessages = []string{
+	"Deadlock found when trying to get lock",
+	"Lock wait timeout exceeded",
+	"deadlock detected",
+	"could not serialize access",
+	"database is locked",
+	"database table is locked",
+	"has been chosen as the deadlock victim",
+	"WSREP detected deadlock/conflict",
+}
+
+func causedByConcurrencyError(err error) bool {
+
+	if err == nil {
+		return false
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return concurrencyErrorCodes[mysqlErr.Number]
+	}
+
+	message := err.Error()
+	for _, needle := range concurrencyErrorMessages {
+		if strings.Contains(message, needle) {
+			return true
+		}
+	}
+
+	return false
+}
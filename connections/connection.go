@@ -1,37 +1,241 @@
 package connections
 
 import (
+	"context"
+	"database/concerns"
 	"database/contracts"
 	"database/kernel/config"
 	"database/query"
+	"database/schema"
 	"database/sql"
+	"errors"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type Connection struct {
-	pdo          *sql.DB
-	config       *config.DatabaseDriver
-	queryGrammar contracts.Grammar
+	*concerns.LogsQueries
+	*concerns.TransactionListeners
+
+	mu            sync.RWMutex
+	reconnectMu   sync.Mutex
+	pdo           *sql.DB
+	readPdos      []*sql.DB
+	config        *config.DatabaseDriver
+	queryGrammar  contracts.Grammar
+	schemaGrammar contracts.SchemaGrammar
+
+	readCursor   uint64
+	disconnected bool
+	reconnector  func() error
 }
 
 func NewConnection(pdo *sql.DB, config *config.DatabaseDriver, grammar contracts.Grammar) *Connection {
 
 	return &Connection{
-		pdo:          pdo,
-		config:       config,
-		queryGrammar: grammar,
+		LogsQueries:          concerns.NewLogsQueries(),
+		TransactionListeners: concerns.NewTransactionListeners(),
+		pdo:                  pdo,
+		config:               config,
+		queryGrammar:         grammar,
 	}
 }
 
 func (c *Connection) GetPDO() *sql.DB {
 
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return c.pdo
 }
 
+func (c *Connection) GetReadPDO() *sql.DB {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if len(c.readPdos) <= 0 {
+		return c.pdo
+	}
+
+	if len(c.readPdos) == 1 {
+		return c.readPdos[0]
+	}
+
+	if c.config.ReadStrategy == "round-robin" {
+		return c.readPdos[(atomic.AddUint64(&c.readCursor, 1)-1)%uint64(len(c.readPdos))]
+	}
+
+	return c.readPdos[rand.Intn(len(c.readPdos))]
+}
+
+func (c *Connection) Stats() sql.DBStats {
+
+	return c.GetPDO().Stats()
+}
+
+func (c *Connection) ReadStats() []sql.DBStats {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	stats := make([]sql.DBStats, 0, len(c.readPdos))
+	for _, pdo := range c.readPdos {
+		stats = append(stats, pdo.Stats())
+	}
+
+	return stats
+}
+
+func (c *Connection) GetReadPDOs() []*sql.DB {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.readPdos
+}
+
+func (c *Connection) SetReadPDOs(pdos []*sql.DB) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.readPdos = pdos
+}
+
+func (c *Connection) SetPDOs(pdo *sql.DB, readPdos []*sql.DB) error {
+
+	c.mu.Lock()
+	previous, previousReads := c.pdo, c.readPdos
+	c.pdo, c.readPdos, c.disconnected = pdo, readPdos, false
+	c.mu.Unlock()
+
+	return closePdos(previous, previousReads)
+}
+
+func (c *Connection) SetReconnector(reconnector func() error) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.reconnector = reconnector
+}
+
+func (c *Connection) Ping(ctx context.Context) error {
+
+	if err := c.reconnectIfMissingConnection(); err != nil {
+		return err
+	}
+
+	if err := c.GetPDO().PingContext(ctx); err != nil {
+		return err
+	}
+
+	for _, pdo := range c.GetReadPDOs() {
+		if err := pdo.PingContext(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Connection) Reconnect() error {
+
+	c.mu.RLock()
+	reconnector := c.reconnector
+	c.mu.RUnlock()
+
+	if reconnector == nil {
+		return errors.New("Lost connection and no reconnector available")
+	}
+
+	return reconnector()
+}
+
+func (c *Connection) Disconnect() error {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.disconnected {
+		return nil
+	}
+
+	c.disconnected = true
+
+	return closePdos(c.pdo, c.readPdos)
+}
+
+func (c *Connection) reconnectIfMissingConnection() error {
+
+	c.mu.RLock()
+	missing := c.disconnected && c.reconnector != nil
+	c.mu.RUnlock()
+
+	if !missing {
+		return nil
+	}
+
+	return c.reconnectFrom(c.GetPDO())
+}
+
+func (c *Connection) reconnectFrom(stale *sql.DB) error {
+
+	c.reconnectMu.Lock()
+	defer c.reconnectMu.Unlock()
+
+	if c.GetPDO() != stale {
+		return nil
+	}
+
+	return c.Reconnect()
+}
+
+func (c *Connection) retryOnLostConnection(callback func() error) error {
+
+	pdo := c.GetPDO()
+
+	err := callback()
+	if !causedByLostConnection(err) {
+		return err
+	}
+
+	c.mu.RLock()
+	hasReconnector := c.reconnector != nil
+	c.mu.RUnlock()
+
+	if hasReconnector {
+		if reconnectErr := c.reconnectFrom(pdo); reconnectErr != nil {
+			return err
+		}
+	}
+
+	return callback()
+}
+
 func (c *Connection) GetGrammar() contracts.Grammar {
 
 	return c.queryGrammar
 }
 
+func (c *Connection) GetSchemaGrammar() contracts.SchemaGrammar {
+
+	return c.schemaGrammar
+}
+
+func (c *Connection) SetSchemaGrammar(grammar contracts.SchemaGrammar) {
+
+	c.schemaGrammar = grammar
+}
+
+func (c *Connection) Schema() contracts.SchemaBuilder {
+
+	return schema.NewBuilder(c, c.schemaGrammar)
+}
+
 func (c *Connection) Query() contracts.QueryBuilder {
 
 	return query.NewBuilder(c, c.queryGrammar)
@@ -44,82 +248,244 @@ func (c *Connection) Table(table string) contracts.QueryBuilder {
 
 func (c *Connection) Select(query string, bindings []interface{}) (*sql.Rows, error) {
 
-	return c.query(c.prepareQuery(query), bindings)
+	return c.SelectContext(context.Background(), query, bindings)
 }
 
-func (c *Connection) Insert(query string, bindings []interface{}) sql.Result {
+func (c *Connection) SelectContext(ctx context.Context, query string, bindings []interface{}) (*sql.Rows, error) {
 
-	return c.statement(c.prepareQuery(query), bindings)
+	return c.selectContext(ctx, query, bindings, true)
 }
 
-func (c *Connection) Update(query string, bindings []interface{}) int64 {
+func (c *Connection) SelectFromWriteConnection(query string, bindings []interface{}) (*sql.Rows, error) {
 
-	return c.affectingStatement(c.prepareQuery(query), bindings)
+	return c.SelectFromWriteConnectionContext(context.Background(), query, bindings)
 }
 
-func (c *Connection) Delete(query string, bindings []interface{}) int64 {
+func (c *Connection) SelectFromWriteConnectionContext(
+	ctx context.Context, query string, bindings []interface{},
+) (*sql.Rows, error) {
 
-	return c.affectingStatement(c.prepareQuery(query), bindings)
+	return c.selectContext(ctx, query, bindings, false)
 }
 
-func (c *Connection) Statement(query string, bindings []interface{}) sql.Result {
+func (c *Connection) MarkRecordsModified(ctx context.Context) {
 
-	return c.statement(c.prepareQuery(query), bindings)
+	markRecordsModified(ctx)
 }
 
-func (c *Connection) query(statement *sql.Stmt, bindings []interface{}) (*sql.Rows, error) {
+func (c *Connection) selectContext(
+	ctx context.Context, query string, bindings []interface{}, useReadPdo bool,
+) (*sql.Rows, error) {
 
-	defer statement.Close()
+	var rows *sql.Rows
+
+	err := c.retryOnLostConnection(func() (err error) {
+		rows, err = c.runSelect(ctx, query, bindings, useReadPdo)
+		return err
+	})
+
+	return rows, err
+}
+
+func (c *Connection) runSelect(
+	ctx context.Context, query string, bindings []interface{}, useReadPdo bool,
+) (*sql.Rows, error) {
+
+	start := time.Now()
+
+	statement, err := c.prepareQuery(ctx, query, useReadPdo)
+	if err != nil {
+		return nil, c.queryFailed(query, bindings, start, err)
+	}
+
+	return c.query(ctx, query, statement, bindings, start)
+}
+
+func (c *Connection) Insert(query string, bindings []interface{}) (sql.Result, error) {
+
+	return c.InsertContext(context.Background(), query, bindings)
+}
+
+func (c *Connection) InsertContext(ctx context.Context, query string, bindings []interface{}) (sql.Result, error) {
+
+	return c.StatementContext(ctx, query, bindings)
+}
+
+func (c *Connection) Update(query string, bindings []interface{}) (int64, error) {
+
+	return c.UpdateContext(context.Background(), query, bindings)
+}
+
+func (c *Connection) UpdateContext(ctx context.Context, query string, bindings []interface{}) (int64, error) {
+
+	start := time.Now()
+
+	statement, err := c.prepareStatement(ctx, query)
+	if err != nil {
+		return 0, c.queryFailed(query, bindings, start, err)
+	}
+
+	return c.affectingStatement(ctx, query, statement, bindings, start)
+}
+
+func (c *Connection) Delete(query string, bindings []interface{}) (int64, error) {
+
+	return c.DeleteContext(context.Background(), query, bindings)
+}
+
+func (c *Connection) DeleteContext(ctx context.Context, query string, bindings []interface{}) (int64, error) {
+
+	return c.UpdateContext(ctx, query, bindings)
+}
+
+func (c *Connection) Statement(query string, bindings []interface{}) (sql.Result, error) {
+
+	return c.StatementContext(context.Background(), query, bindings)
+}
+
+func (c *Connection) StatementContext(ctx context.Context, query string, bindings []interface{}) (sql.Result, error) {
+
+	start := time.Now()
+
+	statement, err := c.prepareStatement(ctx, query)
+	if err != nil {
+		return nil, c.queryFailed(query, bindings, start, err)
+	}
 
-	return statement.Query(bindings...)
+	return c.statement(ctx, query, statement, bindings, start)
 }
 
-func (c *Connection) statement(statement *sql.Stmt, bindings []interface{}) sql.Result {
+func (c *Connection) query(
+	ctx context.Context, query string, statement *sql.Stmt, bindings []interface{}, start time.Time,
+) (*sql.Rows, error) {
 
 	defer statement.Close()
 
-	res, err := statement.Exec(bindings...)
-	prepareError(err)
+	rows, err := statement.QueryContext(ctx, bindings...)
+	if err != nil {
+		return nil, c.queryFailed(query, bindings, start, err)
+	}
+
+	c.logQuery(query, bindings, start, 0, nil)
 
-	return res
+	return rows, nil
 }
 
-func (c *Connection) affectingStatement(statement *sql.Stmt, bindings []interface{}) int64 {
+func (c *Connection) statement(
+	ctx context.Context, query string, statement *sql.Stmt, bindings []interface{}, start time.Time,
+) (sql.Result, error) {
 
 	defer statement.Close()
 
-	res, err := statement.Exec(bindings...)
-	prepareError(err)
+	res, err := statement.ExecContext(ctx, bindings...)
+	if err != nil {
+		return nil, c.queryFailed(query, bindings, start, err)
+	}
+
+	affected, _ := res.RowsAffected()
+	c.logQuery(query, bindings, start, affected, nil)
+
+	markRecordsModified(ctx)
+
+	return res, nil
+}
+
+func (c *Connection) affectingStatement(
+	ctx context.Context, query string, statement *sql.Stmt, bindings []interface{}, start time.Time,
+) (int64, error) {
+
+	res, err := c.statement(ctx, query, statement, bindings, start)
+	if err != nil {
+		return 0, err
+	}
 
 	cont, err := res.RowsAffected()
 	if err != nil {
-		return 0
+		return 0, NewQueryError(query, bindings, err)
 	}
 
-	return cont
+	return cont, nil
+}
+
+func (c *Connection) queryFailed(query string, bindings []interface{}, start time.Time, err error) error {
+
+	queryErr := NewQueryError(query, bindings, err)
+	c.logQuery(query, bindings, start, 0, queryErr)
+
+	return queryErr
+}
+
+func (c *Connection) logQuery(query string, bindings []interface{}, start time.Time, rowsAffected int64, err error) {
+
+	c.LogQuery(contracts.QueryEvent{
+		Sql:          query,
+		Bindings:     bindings,
+		Time:         time.Since(start),
+		RowsAffected: rowsAffected,
+		Err:          err,
+	})
 }
 
-func (c *Connection) prepareQuery(query string) *sql.Stmt {
+func (c *Connection) prepareStatement(ctx context.Context, query string) (*sql.Stmt, error) {
+
+	var statement *sql.Stmt
 
-	statement, err := c.pdo.Prepare(query)
-	prepareError(err)
+	err := c.retryOnLostConnection(func() (err error) {
+		statement, err = c.prepareQuery(ctx, query, false)
+		return err
+	})
 
-	return statement
+	return statement, err
 }
 
-func (c *Connection) Transaction(args ...interface{}) contracts.TransactionConnection {
+func (c *Connection) prepareQuery(ctx context.Context, query string, useReadPdo bool) (*sql.Stmt, error) {
 
-	if len(args) > 0 {
-		tc := NewTransactionConnection(c)
-		return tc.Transaction(args...)
+	if err := c.reconnectIfMissingConnection(); err != nil {
+		return nil, err
 	}
 
-	return NewTransactionConnection(c)
+	return c.getPdoForSelect(ctx, useReadPdo).PrepareContext(ctx, c.queryGrammar.SubstituteParameters(query))
 }
 
-func prepareError(err error) {
+func (c *Connection) getPdoForSelect(ctx context.Context, useReadPdo bool) *sql.DB {
 
-	if err != nil {
-		panic(err)
+	if useReadPdo && !(c.config.Sticky && RecordsHaveBeenModified(ctx)) {
+		return c.GetReadPDO()
+	}
+
+	return c.GetPDO()
+}
+
+func closePdos(pdo *sql.DB, readPdos []*sql.DB) error {
+
+	var err error
+
+	if pdo != nil {
+		err = pdo.Close()
+	}
+
+	for _, readPdo := range readPdos {
+		if closeErr := readPdo.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
 	}
+
+	return err
+}
+
+func (c *Connection) Transaction(ctx context.Context, callback TransactionCallback, attempts int) error {
+
+	return c.TransactionWithOptions(ctx, nil, callback, attempts)
+}
+
+func (c *Connection) TransactionWithOptions(
+	ctx context.Context, opts *sql.TxOptions, callback TransactionCallback, attempts int,
+) error {
+
+	return NewTransactionConnection(c).TransactionWithOptions(ctx, opts, callback, attempts)
+}
+
+func (c *Connection) BeginTx(ctx context.Context, opts *sql.TxOptions) (contracts.TransactionConnection, error) {
+
+	return NewTransactionConnection(c).BeginTx(ctx, opts)
 }
@@ -0,0 +1,49 @@
+package connections
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+)
+
+var lostConnectionErrorMessages = []string{
+	"server has gone away",
+	"no connection to the server",
+	"Lost connection",
+	"is dead or not enabled",
+	"Error while sending",
+	"decryption failed or bad record mac",
+	"server closed the connection unexpectedly",
+	"SSL connection has been closed unexpectedly",
+	"Error writing data to the connection",
+	"Resource deadlock avoided",
+	"invalid connection",
+	"bad connection",
+	"connection reset by peer",
+	"broken pipe",
+	"Physical connection is not usable",
+	"Communication link failure",
+	"Connection refused",
+	"database is closed",
+}
+
+func causedByLostConnection(err error) bool {
+
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) {
+		return true
+	}
+
+	message := err.Error()
+	for _, needle := range lostConnectionErrorMessages {
+		if strings.Contains(message, needle) {
+			return true
+		}
+	}
+
+	return false
+}
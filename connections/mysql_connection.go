@@ -3,6 +3,7 @@ package connections
 import (
 	"database/kernel/config"
 	"database/query/grammars"
+	schemaGrammars "database/schema/grammars"
 	"database/sql"
 )
 
@@ -12,7 +13,13 @@ type MySqlConnection struct {
 
 func NewMysqlConnection(pdo *sql.DB, config *config.DatabaseDriver) *MySqlConnection {
 
+	grammar := grammars.NewMysqlGrammar()
+	grammar.SetUpsertAlias(config.UpsertAlias)
+
+	connection := NewConnection(pdo, config, grammar)
+	connection.SetSchemaGrammar(schemaGrammars.NewMysqlGrammar())
+
 	return &MySqlConnection{
-		Connection: NewConnection(pdo, config, grammars.NewMysqlGrammar()),
+		Connection: connection,
 	}
 }
\ No newline at end of file
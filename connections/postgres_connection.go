@@ -0,0 +1,18 @@
+package connections
+
+import (
+	"database/kernel/config"
+	"database/query/grammars"
+	"database/sql"
+)
+
+type PostgresConnection struct {
+	*Connection
+}
+
+func NewPostgresConnection(pdo *sql.DB, config *config.DatabaseDriver) *PostgresConnection {
+
+	return &PostgresConnection{
+		Connection: NewConnection(pdo, config, grammars.NewPostgresGrammar()),
+	}
+}
@@ -0,0 +1,26 @@
+package connections
+
+type QueryError struct {
+	Sql      string
+	Bindings []interface{}
+	Err      error
+}
+
+func NewQueryError(sql string, bindings []interface{}, err error) *QueryError {
+
+	return &QueryError{
+		Sql:      sql,
+		Bindings: bindings,
+		Err:      err,
+	}
+}
+
+func (e *QueryError) Error() string {
+
+	return e.Err.Error() + " (SQL: " + e.Sql + ")"
+}
+
+func (e *QueryError) Unwrap() error {
+
+	return e.Err
+}
@@ -0,0 +1,18 @@
+package connections
+
+import (
+	"database/kernel/config"
+	"database/query/grammars"
+	"database/sql"
+)
+
+type SqliteConnection struct {
+	*Connection
+}
+
+func NewSqliteConnection(pdo *sql.DB, config *config.DatabaseDriver) *SqliteConnection {
+
+	return &SqliteConnection{
+		Connection: NewConnection(pdo, config, grammars.NewSqliteGrammar()),
+	}
+}
@@ -0,0 +1,18 @@
+package connections
+
+import (
+	"database/kernel/config"
+	"database/query/grammars"
+	"database/sql"
+)
+
+type SqlServerConnection struct {
+	*Connection
+}
+
+func NewSqlServerConnection(pdo *sql.DB, config *config.DatabaseDriver) *SqlServerConnection {
+
+	return &SqlServerConnection{
+		Connection: NewConnection(pdo, config, grammars.NewSqlServerGrammar()),
+	}
+}
@@ -0,0 +1,36 @@
+package connections
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type recordsModifiedKey struct{}
+
+func WithStickyReads(ctx context.Context) context.Context {
+
+	return context.WithValue(ctx, recordsModifiedKey{}, new(int32))
+}
+
+func RecordsHaveBeenModified(ctx context.Context) bool {
+
+	if modified, ok := ctx.Value(recordsModifiedKey{}).(*int32); ok {
+		return atomic.LoadInt32(modified) == 1
+	}
+
+	return false
+}
+
+func ForgetRecordModificationState(ctx context.Context) {
+
+	if modified, ok := ctx.Value(recordsModifiedKey{}).(*int32); ok {
+		atomic.StoreInt32(modified, 0)
+	}
+}
+
+func markRecordsModified(ctx context.Context) {
+
+	if modified, ok := ctx.Value(recordsModifiedKey{}).(*int32); ok {
+		atomic.StoreInt32(modified, 1)
+	}
+}
@@ -0,0 +1,87 @@
+package connections_test
+
+import (
+	"context"
+	"database/connections"
+	"database/internal/fakedb"
+	"database/kernel/config"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+func newReplicatedConnection(sticky bool) (*fakedb.Server, *fakedb.Server, *connections.SqliteConnection) {
+
+	write, read := fakedb.New(), fakedb.New()
+
+	connection := connections.NewSqliteConnection(write.Open(), &config.DatabaseDriver{Sticky: sticky})
+	connection.SetReadPDOs([]*sql.DB{read.Open()})
+
+	return write, read, connection
+}
+
+func TestReadsUseTheReplicaUntilRecordsAreModified(t *testing.T) {
+
+	write, read, connection := newReplicatedConnection(true)
+	ctx := connections.WithStickyReads(context.Background())
+
+	var names []string
+	if err := connection.Table("users").WithContext(ctx).Pluck("name", &names); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := connection.Table("users").WithContext(ctx).Where("id", 1).Delete(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := connection.Table("users").WithContext(ctx).Pluck("name", &names); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(read.Queries()) != 1 || len(write.Queries()) != 2 {
+		t.Errorf("unexpected routing\nread: %q\nwrite: %q", read.Queries(), write.Queries())
+	}
+}
+
+func TestReturningInsertsMarkRecordsModified(t *testing.T) {
+
+	write, read, connection := newReplicatedConnection(true)
+	ctx := connections.WithStickyReads(context.Background())
+
+	write.QueueRows([]string{"id"}, []driver.Value{int64(7)})
+	write.QueueRows([]string{"name"}, []driver.Value{"Jane"})
+
+	if _, err := connection.Table("users").WithContext(ctx).InsertGetId(map[string]interface{}{"name": "Jane"}, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if !connections.RecordsHaveBeenModified(ctx) {
+		t.Fatal("expected a returning insert to mark the context as modified")
+	}
+
+	if _, err := connection.Table("users").WithContext(ctx).Where("id", 7).Value("name"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(read.Queries()) > 0 || len(write.Queries()) != 2 {
+		t.Errorf("unexpected routing\nread: %q\nwrite: %q", read.Queries(), write.Queries())
+	}
+}
+
+func TestReadsIgnoreModificationsWithoutSticky(t *testing.T) {
+
+	write, read, connection := newReplicatedConnection(false)
+	ctx := connections.WithStickyReads(context.Background())
+
+	if _, err := connection.Table("users").WithContext(ctx).Where("id", 1).Delete(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := connection.Table("users").WithContext(ctx).Where("id", 1).Value("name"); err != sql.ErrNoRows {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+
+	if len(read.Queries()) != 1 || len(write.Queries()) != 1 {
+		t.Errorf("unexpected routing\nread: %q\nwrite: %q", read.Queries(), write.Queries())
+	}
+}
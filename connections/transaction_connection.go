@@ -1,10 +1,15 @@
 package connections
 
 import (
+	"context"
 	"database/concerns"
 	"database/contracts"
 	"database/query"
+	"database/schema"
 	"database/sql"
+	"errors"
+	"fmt"
+	"time"
 )
 
 type TransactionConnection struct {
@@ -17,6 +22,18 @@ type TransactionCallback = func(tc contracts.TransactionConnection) error
 func NewTransactionConnection(c *Connection) contracts.TransactionConnection {
 
 	tm := concerns.NewManagesTransactions(c.GetPDO(), c.GetGrammar())
+	tm.SetConnectionListeners(c.TransactionListeners)
+	tm.SetPDOResolver(func() (*sql.DB, error) {
+		if err := c.reconnectIfMissingConnection(); err != nil {
+			return nil, err
+		}
+
+		return c.GetPDO(), nil
+	})
+
+	if isolation, err := concerns.ParseIsolationLevel(c.config.Isolation); err == nil {
+		tm.SetDefaultIsolation(isolation)
+	}
 
 	return &TransactionConnection{
 		c,
@@ -24,6 +41,26 @@ func NewTransactionConnection(c *Connection) contracts.TransactionConnection {
 	}
 }
 
+func (tc *TransactionConnection) OnBegin(listener func(level int)) {
+
+	tc.ManagesTransactions.OnBegin(listener)
+}
+
+func (tc *TransactionConnection) OnCommit(listener func(level int)) {
+
+	tc.ManagesTransactions.OnCommit(listener)
+}
+
+func (tc *TransactionConnection) OnRollback(listener func(level int)) {
+
+	tc.ManagesTransactions.OnRollback(listener)
+}
+
+func (tc *TransactionConnection) Schema() contracts.SchemaBuilder {
+
+	return schema.NewBuilder(tc, tc.GetSchemaGrammar())
+}
+
 func (tc *TransactionConnection) Query() contracts.QueryBuilder {
 
 	return query.NewBuilder(tc, tc.GetGrammar())
@@ -36,56 +73,213 @@ func (tc *TransactionConnection) Table(table string) contracts.QueryBuilder {
 
 func (tc *TransactionConnection) Select(query string, bindings []interface{}) (*sql.Rows, error) {
 
-	return tc.query(tc.prepareQuery(query), bindings)
+	return tc.SelectContext(context.Background(), query, bindings)
+}
+
+func (tc *TransactionConnection) SelectContext(ctx context.Context, query string, bindings []interface{}) (*sql.Rows, error) {
+
+	return tc.selectContext(ctx, query, bindings, true)
+}
+
+func (tc *TransactionConnection) SelectFromWriteConnection(query string, bindings []interface{}) (*sql.Rows, error) {
+
+	return tc.SelectFromWriteConnectionContext(context.Background(), query, bindings)
+}
+
+func (tc *TransactionConnection) SelectFromWriteConnectionContext(
+	ctx context.Context, query string, bindings []interface{},
+) (*sql.Rows, error) {
+
+	return tc.selectContext(ctx, query, bindings, false)
+}
+
+func (tc *TransactionConnection) selectContext(
+	ctx context.Context, query string, bindings []interface{}, useReadPdo bool,
+) (*sql.Rows, error) {
+
+	var rows *sql.Rows
+
+	err := tc.retryOnLostConnection(func() (err error) {
+		rows, err = tc.runSelect(ctx, query, bindings, useReadPdo)
+		return err
+	})
+
+	return rows, err
+}
+
+func (tc *TransactionConnection) runSelect(
+	ctx context.Context, query string, bindings []interface{}, useReadPdo bool,
+) (*sql.Rows, error) {
+
+	start := time.Now()
+
+	statement, err := tc.prepareQuery(ctx, query, useReadPdo)
+	if err != nil {
+		return nil, tc.queryFailed(query, bindings, start, err)
+	}
+
+	return tc.query(ctx, query, statement, bindings, start)
+}
+
+func (tc *TransactionConnection) Insert(query string, bindings []interface{}) (sql.Result, error) {
+
+	return tc.InsertContext(context.Background(), query, bindings)
+}
+
+func (tc *TransactionConnection) InsertContext(ctx context.Context, query string, bindings []interface{}) (sql.Result, error) {
+
+	return tc.StatementContext(ctx, query, bindings)
+}
+
+func (tc *TransactionConnection) Update(query string, bindings []interface{}) (int64, error) {
+
+	return tc.UpdateContext(context.Background(), query, bindings)
 }
 
-func (tc *TransactionConnection) Insert(query string, bindings []interface{}) sql.Result {
+func (tc *TransactionConnection) UpdateContext(ctx context.Context, query string, bindings []interface{}) (int64, error) {
 
-	return tc.statement(tc.prepareQuery(query), bindings)
+	start := time.Now()
+
+	statement, err := tc.prepareStatement(ctx, query)
+	if err != nil {
+		return 0, tc.queryFailed(query, bindings, start, err)
+	}
+
+	return tc.affectingStatement(ctx, query, statement, bindings, start)
+}
+
+func (tc *TransactionConnection) Delete(query string, bindings []interface{}) (int64, error) {
+
+	return tc.DeleteContext(context.Background(), query, bindings)
+}
+
+func (tc *TransactionConnection) DeleteContext(ctx context.Context, query string, bindings []interface{}) (int64, error) {
+
+	return tc.UpdateContext(ctx, query, bindings)
+}
+
+func (tc *TransactionConnection) Statement(query string, bindings []interface{}) (sql.Result, error) {
+
+	return tc.StatementContext(context.Background(), query, bindings)
 }
 
-func (tc *TransactionConnection) Update(query string, bindings []interface{}) int64 {
+func (tc *TransactionConnection) StatementContext(ctx context.Context, query string, bindings []interface{}) (sql.Result, error) {
+
+	start := time.Now()
+
+	statement, err := tc.prepareStatement(ctx, query)
+	if err != nil {
+		return nil, tc.queryFailed(query, bindings, start, err)
+	}
 
-	return tc.affectingStatement(tc.prepareQuery(query), bindings)
+	return tc.statement(ctx, query, statement, bindings, start)
 }
 
-func (tc *TransactionConnection) Delete(query string, bindings []interface{}) int64 {
+func (tc *TransactionConnection) prepareStatement(ctx context.Context, query string) (*sql.Stmt, error) {
+
+	var statement *sql.Stmt
+
+	err := tc.retryOnLostConnection(func() (err error) {
+		statement, err = tc.prepareQuery(ctx, query, false)
+		return err
+	})
 
-	return tc.affectingStatement(tc.prepareQuery(query), bindings)
+	return statement, err
 }
 
-func (tc *TransactionConnection) prepareQuery(query string) *sql.Stmt {
+func (tc *TransactionConnection) prepareQuery(ctx context.Context, query string, useReadPdo bool) (*sql.Stmt, error) {
+
+	query = tc.GetGrammar().SubstituteParameters(query)
+
+	if tc.TransactionLevel() > 0 {
+		return tc.GetTxPDO().PrepareContext(ctx, query)
+	}
+
+	if err := tc.reconnectIfMissingConnection(); err != nil {
+		return nil, err
+	}
+
+	return tc.getPdoForSelect(ctx, useReadPdo).PrepareContext(ctx, query)
+}
 
-	var (
-		err       error
-		statement *sql.Stmt
-	)
+func (tc *TransactionConnection) retryOnLostConnection(callback func() error) error {
 
 	if tc.TransactionLevel() > 0 {
-		statement, err = tc.GetTxPDO().Prepare(query)
-	} else {
-		statement, err = tc.GetPDO().Prepare(query)
+		return callback()
 	}
 
-	prepareError(err)
+	return tc.Connection.retryOnLostConnection(callback)
+}
+
+func (tc *TransactionConnection) Transaction(ctx context.Context, callback TransactionCallback, attempts int) error {
 
-	return statement
+	return tc.TransactionWithOptions(ctx, nil, callback, attempts)
 }
 
-func (tc *TransactionConnection) Transaction(args ...interface{}) contracts.TransactionConnection {
+func (tc *TransactionConnection) TransactionWithOptions(
+	ctx context.Context, opts *sql.TxOptions, callback TransactionCallback, attempts int,
+) error {
+
+	if callback == nil {
+		return errors.New("Unresolved transaction callback")
+	}
+
+	for attempt := 1; ; attempt++ {
+		if err := tc.BeginTransactionContext(ctx, opts); err != nil {
+			return err
+		}
+
+		level := tc.TransactionLevel()
+
+		err := tc.runTransactionCallback(callback)
+		if err == nil {
+			if err = tc.Commit(); err == nil {
+				return nil
+			}
+		}
+
+		if rollbackErr := tc.RollBack(level - 1); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			return rollbackErr
+		}
 
-	callback, ok := args[0].(TransactionCallback)
-	if !ok {
-		panic("Unresolved transaction params")
+		if level > 1 || attempt >= attempts || !causedByConcurrencyError(err) {
+			return err
+		}
 	}
+}
+
+func (tc *TransactionConnection) BeginTransaction() error {
+
+	return tc.BeginTransactionContext(context.Background(), nil)
+}
+
+func (tc *TransactionConnection) BeginTransactionContext(ctx context.Context, opts *sql.TxOptions) error {
 
-	tc.BeginTransaction()
+	return tc.retryOnLostConnection(func() error {
+		return tc.ManagesTransactions.BeginTransactionContext(ctx, opts)
+	})
+}
 
-	if err := callback(tc); err != nil {
-		tc.RollBack(nil)
-	} else {
-		tc.Commit()
+func (tc *TransactionConnection) BeginTx(ctx context.Context, opts *sql.TxOptions) (contracts.TransactionConnection, error) {
+
+	if err := tc.BeginTransactionContext(ctx, opts); err != nil {
+		return tc, err
 	}
 
-	return tc
+	return tc, nil
+}
+
+func (tc *TransactionConnection) runTransactionCallback(callback TransactionCallback) (err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			if recovered, ok := r.(error); ok {
+				err = fmt.Errorf("Transaction aborted by panic: %w", recovered)
+			} else {
+				err = fmt.Errorf("Transaction aborted by panic: %v", r)
+			}
+		}
+	}()
+
+	return callback(tc)
 }
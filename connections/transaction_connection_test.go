@@ -0,0 +1,289 @@
+package connections_test
+
+import (
+	"context"
+	"database/connections"
+	"database/contracts"
+	"database/internal/fakedb"
+	"database/kernel/config"
+	"errors"
+	"github.com/go-sql-driver/mysql"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func newMysqlConnection() (*fakedb.Server, *connections.MySqlConnection) {
+
+	server := fakedb.New()
+
+	return server, connections.NewMysqlConnection(server.Open(), &config.DatabaseDriver{})
+}
+
+func assertQueries(t *testing.T, server *fakedb.Server, expected ...string) {
+
+	t.Helper()
+
+	if queries := server.Queries(); !reflect.DeepEqual(queries, expected) {
+		t.Errorf("queries mismatch\n got: %q\nwant: %q", queries, expected)
+	}
+}
+
+func TestNestedTransactionsUseSavepoints(t *testing.T) {
+
+	server, connection := newMysqlConnection()
+	ctx := context.Background()
+
+	innerErr := errors.New("inner failed")
+
+	err := connection.Transaction(ctx, func(tc contracts.TransactionConnection) error {
+		if _, err := tc.Table("users").Where("id", 1).Update(map[string]interface{}{"name": "a"}); err != nil {
+			return err
+		}
+
+		err := tc.Transaction(ctx, func(inner contracts.TransactionConnection) error {
+			if _, err := inner.Table("users").Where("id", 2).Delete(); err != nil {
+				return err
+			}
+
+			return innerErr
+		}, 1)
+		if err != innerErr {
+			t.Errorf("expected the inner callback error, got %v", err)
+		}
+
+		if tc.TransactionLevel() != 1 {
+			t.Errorf("expected level 1 after the inner rollback, got %d", tc.TransactionLevel())
+		}
+
+		return nil
+	}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertQueries(t, server,
+		"BEGIN",
+		"update `users` set `name` = ? where `id` = ?",
+		"SAVEPOINT trans2",
+		"delete from `users` where `id` = ?",
+		"ROLLBACK TO SAVEPOINT trans2",
+		"COMMIT",
+	)
+}
+
+func TestTransactionRetriesDeadlocks(t *testing.T) {
+
+	server, connection := newMysqlConnection()
+
+	server.QueueError(&mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"})
+	server.QueueError(&mysql.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded"})
+
+	attempts := 0
+	err := connection.Transaction(context.Background(), func(tc contracts.TransactionConnection) error {
+		attempts++
+		_, err := tc.Table("users").Where("id", 1).Update(map[string]interface{}{"name": "a"})
+		return err
+	}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+
+	update := "update `users` set `name` = ? where `id` = ?"
+	assertQueries(t, server,
+		"BEGIN", update, "ROLLBACK",
+		"BEGIN", update, "ROLLBACK",
+		"BEGIN", update, "COMMIT",
+	)
+}
+
+func TestTransactionGivesUpAfterTheLastAttempt(t *testing.T) {
+
+	server, connection := newMysqlConnection()
+
+	deadlock := &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+	server.QueueError(deadlock)
+	server.QueueError(deadlock)
+
+	err := connection.Transaction(context.Background(), func(tc contracts.TransactionConnection) error {
+		_, err := tc.Table("users").Where("id", 1).Update(map[string]interface{}{"name": "a"})
+		return err
+	}, 2)
+
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) || mysqlErr.Number != 1213 {
+		t.Errorf("expected the deadlock error, got %v", err)
+	}
+
+	if len(server.Queries()) != 6 {
+		t.Errorf("expected two attempts, got %q", server.Queries())
+	}
+}
+
+func TestTransactionDoesNotRetryOtherErrors(t *testing.T) {
+
+	server, connection := newMysqlConnection()
+
+	failure := errors.New("boom")
+	attempts := 0
+
+	err := connection.Transaction(context.Background(), func(tc contracts.TransactionConnection) error {
+		attempts++
+		return failure
+	}, 5)
+	if err != failure {
+		t.Errorf("expected the callback error, got %v", err)
+	}
+
+	if attempts != 1 {
+		t.Errorf("expected a single attempt, got %d", attempts)
+	}
+
+	assertQueries(t, server, "BEGIN", "ROLLBACK")
+}
+
+func TestTransactionRollsBackOnPanic(t *testing.T) {
+
+	server, connection := newMysqlConnection()
+
+	err := connection.Transaction(context.Background(), func(tc contracts.TransactionConnection) error {
+		panic("unexpected")
+	}, 1)
+	if err == nil {
+		t.Fatal("expected the panic to be returned as an error")
+	}
+
+	assertQueries(t, server, "BEGIN", "ROLLBACK")
+}
+
+func TestAfterCommitRunsOnlyWhenTheOutermostTransactionCommits(t *testing.T) {
+
+	_, connection := newMysqlConnection()
+	ctx := context.Background()
+
+	var fired []string
+
+	err := connection.Transaction(ctx, func(tc contracts.TransactionConnection) error {
+		tc.AfterCommit(func() { fired = append(fired, "outer") })
+
+		tc.Transaction(ctx, func(inner contracts.TransactionConnection) error {
+			inner.AfterCommit(func() { fired = append(fired, "committed savepoint") })
+			return nil
+		}, 1)
+
+		tc.Transaction(ctx, func(inner contracts.TransactionConnection) error {
+			inner.AfterCommit(func() { fired = append(fired, "rolled back savepoint") })
+			return errors.New("rollback")
+		}, 1)
+
+		if len(fired) > 0 {
+			t.Errorf("callbacks fired before the outer commit: %v", fired)
+		}
+
+		return nil
+	}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(fired, []string{"outer", "committed savepoint"}) {
+		t.Errorf("unexpected callbacks %v", fired)
+	}
+
+	fired = nil
+	connection.Transaction(ctx, func(tc contracts.TransactionConnection) error {
+		tc.AfterCommit(func() { fired = append(fired, "rolled back") })
+		return errors.New("rollback")
+	}, 1)
+
+	if len(fired) > 0 {
+		t.Errorf("callbacks of a rolled back transaction fired: %v", fired)
+	}
+}
+
+func TestTransactionListeners(t *testing.T) {
+
+	_, connection := newMysqlConnection()
+	ctx := context.Background()
+
+	var events []string
+	connection.OnBegin(func(level int) { events = append(events, "connection begin "+strconv.Itoa(level)) })
+	connection.OnCommit(func(level int) { events = append(events, "connection commit "+strconv.Itoa(level)) })
+	connection.OnRollback(func(level int) { events = append(events, "connection rollback "+strconv.Itoa(level)) })
+
+	connection.Transaction(ctx, func(tc contracts.TransactionConnection) error {
+		tc.OnCommit(func(level int) { events = append(events, "tx commit "+strconv.Itoa(level)) })
+
+		return tc.Transaction(ctx, func(inner contracts.TransactionConnection) error {
+			return nil
+		}, 1)
+	}, 1)
+
+	connection.Transaction(ctx, func(tc contracts.TransactionConnection) error {
+		return errors.New("rollback")
+	}, 1)
+
+	expected := []string{
+		"connection begin 1",
+		"connection begin 2",
+		"connection commit 1",
+		"tx commit 1",
+		"connection commit 0",
+		"tx commit 0",
+		"connection begin 1",
+		"connection rollback 0",
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("events mismatch\n got: %q\nwant: %q", events, expected)
+	}
+}
+
+func TestFailedCommitDiscardsListenersAndCallbacks(t *testing.T) {
+
+	server, connection := newMysqlConnection()
+	ctx := context.Background()
+
+	server.QueueCommitError(errors.New("commit failed"))
+
+	tc, err := connection.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fired []string
+	tc.OnCommit(func(level int) { fired = append(fired, "commit listener") })
+	tc.OnRollback(func(level int) { fired = append(fired, "rollback listener") })
+	tc.AfterCommit(func() { fired = append(fired, "after commit") })
+
+	if err := tc.Commit(); err == nil {
+		t.Fatal("expected the commit to fail")
+	}
+
+	if tc.TransactionLevel() != 0 {
+		t.Errorf("expected level 0 after a failed commit, got %d", tc.TransactionLevel())
+	}
+
+	if err := tc.BeginTransaction(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tc.RollBack(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tc.BeginTransaction(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tc.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fired) > 0 {
+		t.Errorf("listeners of the failed transaction fired later: %v", fired)
+	}
+}
@@ -0,0 +1,85 @@
+package connections_test
+
+import (
+	"context"
+	"database/connections"
+	"database/contracts"
+	"database/internal/fakedb"
+	"database/kernel/config"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+func noop(tc contracts.TransactionConnection) error {
+
+	return nil
+}
+
+func TestTransactionOptionsArePassedToTheDriver(t *testing.T) {
+
+	server, connection := newMysqlConnection()
+
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	if err := connection.TransactionWithOptions(context.Background(), opts, noop, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := driver.TxOptions{Isolation: driver.IsolationLevel(sql.LevelRepeatableRead), ReadOnly: true}
+	if txOptions := server.TxOptions(); len(txOptions) != 1 || txOptions[0] != expected {
+		t.Errorf("unexpected transaction options %+v", txOptions)
+	}
+
+	assertQueries(t, server, "BEGIN", "COMMIT")
+}
+
+func TestDefaultIsolationFromConfig(t *testing.T) {
+
+	server := fakedb.New()
+	connection := connections.NewMysqlConnection(server.Open(), &config.DatabaseDriver{Isolation: "read_committed"})
+
+	if err := connection.Transaction(context.Background(), noop, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if txOptions := server.TxOptions(); len(txOptions) != 1 || txOptions[0].Isolation != driver.IsolationLevel(sql.LevelReadCommitted) {
+		t.Errorf("unexpected transaction options %+v", txOptions)
+	}
+}
+
+func TestSqlServerIsolationDoesNotLeakIntoThePool(t *testing.T) {
+
+	server := fakedb.New()
+	connection := connections.NewSqlServerConnection(server.Open(), &config.DatabaseDriver{})
+
+	opts := &sql.TxOptions{Isolation: sql.LevelSnapshot}
+	if err := connection.TransactionWithOptions(context.Background(), opts, noop, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if txOptions := server.TxOptions(); len(txOptions) != 1 || txOptions[0].Isolation != driver.IsolationLevel(sql.LevelSnapshot) {
+		t.Errorf("unexpected transaction options %+v", txOptions)
+	}
+
+	assertQueries(t, server, "BEGIN", "COMMIT")
+
+	readOnly := &sql.TxOptions{ReadOnly: true}
+	if err := connection.TransactionWithOptions(context.Background(), readOnly, noop, 1); err == nil {
+		t.Error("expected sql server to reject read-only transactions")
+	}
+}
+
+func TestSqliteRejectsReadOnlyTransactions(t *testing.T) {
+
+	server := fakedb.New()
+	connection := connections.NewSqliteConnection(server.Open(), &config.DatabaseDriver{})
+
+	readOnly := &sql.TxOptions{ReadOnly: true}
+	if err := connection.TransactionWithOptions(context.Background(), readOnly, noop, 1); err == nil {
+		t.Error("expected sqlite to reject read-only transactions")
+	}
+
+	if len(server.Queries()) > 0 {
+		t.Errorf("expected no statements, got %q", server.Queries())
+	}
+}
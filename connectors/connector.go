@@ -3,6 +3,8 @@ package connectors
 import (
 	"database/kernel/config"
 	"database/sql"
+	"fmt"
+	"sort"
 )
 
 type Connector struct {
@@ -16,16 +18,60 @@ func NewConnector(config *config.DatabaseDriver) *Connector {
 	}
 }
 
-func (c *Connector) CreateConnection(dsn string) *sql.DB {
+func (c *Connector) CreateConnection(driver string, dsn string) (*sql.DB, error) {
 
-	connectParams := c.config.Host + ":" + c.config.Port
-	authParams := c.config.Username + ":" + c.config.Password
+	connection, err := c.open(driver, dsn)
 
-	connection, err := sql.Open(dsn, authParams + "@tcp(" + connectParams + ")/" + c.config.Database)
+	if err != nil {
+		return nil, fmt.Errorf("No connection to server. Error: %w", err)
+	}
+
+	c.configurePool(connection)
+
+	return connection, nil
+}
+
+func (c *Connector) open(driver string, dsn string) (*sql.DB, error) {
 
+	if len(c.config.InitStatements) <= 0 {
+		return sql.Open(driver, dsn)
+	}
+
+	connector, err := newSessionConnector(driver, dsn, c.config.InitStatements)
 	if err != nil {
-		panic("No connection to server. Error: " + err.Error())
+		return nil, err
+	}
+
+	return sql.OpenDB(connector), nil
+}
+
+func (c *Connector) configurePool(connection *sql.DB) {
+
+	if c.config.MaxOpenConns > 0 {
+		connection.SetMaxOpenConns(c.config.MaxOpenConns)
+	}
+
+	if c.config.MaxIdleConns != 0 {
+		connection.SetMaxIdleConns(c.config.MaxIdleConns)
+	}
+
+	if c.config.ConnMaxLifetime > 0 {
+		connection.SetConnMaxLifetime(c.config.ConnMaxLifetime)
+	}
+
+	if c.config.ConnMaxIdleTime > 0 {
+		connection.SetConnMaxIdleTime(c.config.ConnMaxIdleTime)
+	}
+}
+
+func sortedOptionKeys(options map[string]string) []string {
+
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
 	}
 
-	return connection
+	sort.Strings(keys)
+
+	return keys
 }
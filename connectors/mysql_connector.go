@@ -1,9 +1,17 @@
 package connectors
 
 import (
+	"crypto/sha1"
+	"crypto/tls"
+	"crypto/x509"
 	"database/kernel/config"
 	"database/sql"
-	_ "github.com/go-sql-driver/mysql"
+	"encoding/hex"
+	"errors"
+	"github.com/go-sql-driver/mysql"
+	"net"
+	"os"
+	"time"
 )
 
 type MySqlConnector struct {
@@ -18,59 +26,146 @@ func NewMysqlConnector(config *config.DatabaseDriver) *MySqlConnector {
 	}
 }
 
-func (m *MySqlConnector) Connect() *sql.DB {
+func (m *MySqlConnector) Connect() (*sql.DB, error) {
 
-	connection := m.Connector.CreateConnection("mysql")
+	dsn, err := m.getDsn()
+	if err != nil {
+		return nil, err
+	}
 
-	m.configureEncoding(connection)
+	return m.Connector.CreateConnection("mysql", dsn)
+}
 
-	m.configureTimezone(connection)
+func (m *MySqlConnector) getDsn() (string, error) {
 
-	m.setModes(connection)
+	if len(m.config.Dsn) > 0 {
+		return m.config.Dsn, nil
+	}
 
-	return connection
+	dsn, err := m.getConfig()
+	if err != nil {
+		return "", err
+	}
+
+	return dsn.FormatDSN(), nil
 }
 
-func (m *MySqlConnector) configureEncoding(connection *sql.DB) {
+func (m *MySqlConnector) getConfig() (*mysql.Config, error) {
 
-	stmt, err := connection.Prepare("set names '" + m.config.Charset + "' collate " + m.config.Collation)
+	dsn := mysql.NewConfig()
+	dsn.User = m.config.Username
+	dsn.Passwd = m.config.Password
+	dsn.DBName = m.config.Database
+	dsn.Params = m.getParams()
 
-	prepareError(err)
+	if len(m.config.UnixSocket) > 0 {
+		dsn.Net = "unix"
+		dsn.Addr = m.config.UnixSocket
+	} else {
+		dsn.Net = "tcp"
+		dsn.Addr = m.config.Host
 
-	stmt.Exec()
-}
+		if len(m.config.Port) > 0 {
+			dsn.Addr = net.JoinHostPort(m.config.Host, m.config.Port)
+		}
+	}
 
-func (m *MySqlConnector) configureTimezone(connection *sql.DB) {
+	if len(m.config.Collation) > 0 {
+		dsn.Collation = m.config.Collation
+	}
 
 	if len(m.config.Timezone) > 0 {
+		if loc, err := time.LoadLocation(m.config.Timezone); err == nil {
+			dsn.Loc = loc
+		}
+	}
 
-		stmt, err := connection.Prepare("set time_zone='" + m.config.Timezone + "'")
-
-		prepareError(err)
+	dsn.ParseTime = m.config.ParseTime
+	dsn.ReadTimeout = m.config.ReadTimeout
+	dsn.WriteTimeout = m.config.WriteTimeout
 
-		stmt.Exec()
+	tlsConfig, err := m.getTlsConfig()
+	if err != nil {
+		return nil, err
 	}
+	dsn.TLSConfig = tlsConfig
+
+	return dsn, nil
 }
 
-func (m *MySqlConnector) setModes(connection *sql.DB) {
+func (m *MySqlConnector) getParams() map[string]string {
+
+	params := map[string]string{}
+
+	if len(m.config.Charset) > 0 {
+		params["charset"] = m.config.Charset
+	}
+
+	if len(m.config.Timezone) > 0 {
+		params["time_zone"] = "'" + m.config.Timezone + "'"
+	}
 
 	if m.config.Strict == true {
+		params["sql_mode"] = "'" + m.getStrictMode() + "'"
+	}
 
-		stmt, err := connection.Prepare(m.getStrictMode())
+	for key, value := range m.config.Options {
+		params[key] = value
+	}
+
+	return params
+}
 
-		prepareError(err)
+func (m *MySqlConnector) getTlsConfig() (string, error) {
 
-		stmt.Exec()
+	if len(m.config.SslCa) <= 0 && len(m.config.SslCert) <= 0 && len(m.config.SslKey) <= 0 {
+		return m.config.SslMode, nil
 	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         m.config.Host,
+		InsecureSkipVerify: m.config.SslMode == "skip-verify",
+	}
+
+	if len(m.config.SslCa) > 0 {
+		pem, err := os.ReadFile(m.config.SslCa)
+		if err != nil {
+			return "", err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return "", errors.New("Failed to append PEM from " + m.config.SslCa)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(m.config.SslCert) > 0 || len(m.config.SslKey) > 0 {
+		certificate, err := tls.LoadX509KeyPair(m.config.SslCert, m.config.SslKey)
+		if err != nil {
+			return "", err
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	name := m.getTlsConfigName()
+	if err := mysql.RegisterTLSConfig(name, tlsConfig); err != nil {
+		return "", err
+	}
+
+	return name, nil
 }
 
-func (m *MySqlConnector) getStrictMode() string {
+func (m *MySqlConnector) getTlsConfigName() string {
 
-	return "set session sql_mode='ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ZERO_IN_DATE,NO_ZERO_DATE,ERROR_FOR_DIVISION_BY_ZERO,NO_AUTO_CREATE_USER,NO_ENGINE_SUBSTITUTION'";
+	hash := sha1.Sum([]byte(m.config.Host + "|" + m.config.SslCa + "|" + m.config.SslCert + "|" + m.config.SslKey + "|" + m.config.SslMode))
+
+	return "custom-" + hex.EncodeToString(hash[:8])
 }
 
-func prepareError(err error) {
-	if err != nil {
-		panic(err)
-	}
+func (m *MySqlConnector) getStrictMode() string {
+
+	return "ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ZERO_IN_DATE,NO_ZERO_DATE,ERROR_FOR_DIVISION_BY_ZERO,NO_ENGINE_SUBSTITUTION"
 }
\ No newline at end of file
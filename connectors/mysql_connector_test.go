@@ -0,0 +1,209 @@
+package connectors
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"database/kernel/config"
+	"encoding/pem"
+	"github.com/go-sql-driver/mysql"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMysqlSessionParams(t *testing.T) {
+
+	connector := NewMysqlConnector(&config.DatabaseDriver{
+		Charset:  "utf8mb4",
+		Timezone: "+00:00",
+		Strict:   true,
+		Options:  map[string]string{"interpolateParams": "true"},
+	})
+
+	expected := map[string]string{
+		"charset":           "utf8mb4",
+		"time_zone":         "'+00:00'",
+		"sql_mode":          "'" + connector.getStrictMode() + "'",
+		"interpolateParams": "true",
+	}
+
+	if params := connector.getParams(); !reflect.DeepEqual(params, expected) {
+		t.Errorf("params mismatch\n got: %v\nwant: %v", params, expected)
+	}
+
+	if strings.Contains(connector.getStrictMode(), "NO_AUTO_CREATE_USER") {
+		t.Error("the strict sql_mode must not contain NO_AUTO_CREATE_USER, which MySQL 8 rejects")
+	}
+}
+
+func TestMysqlConfig(t *testing.T) {
+
+	cases := []struct {
+		name   string
+		config *config.DatabaseDriver
+		check  func(t *testing.T, dsn *mysql.Config)
+	}{
+		{
+			"tcp",
+			&config.DatabaseDriver{Host: "db", Port: "3307", Database: "app", Username: "root", Password: "p@ss/word"},
+			func(t *testing.T, dsn *mysql.Config) {
+				if dsn.Net != "tcp" || dsn.Addr != "db:3307" || dsn.DBName != "app" {
+					t.Errorf("unexpected address %s(%s)/%s", dsn.Net, dsn.Addr, dsn.DBName)
+				}
+				if dsn.User != "root" || dsn.Passwd != "p@ss/word" {
+					t.Errorf("unexpected credentials %s:%s", dsn.User, dsn.Passwd)
+				}
+			},
+		},
+		{
+			"ipv6",
+			&config.DatabaseDriver{Host: "::1", Port: "3306"},
+			func(t *testing.T, dsn *mysql.Config) {
+				if dsn.Addr != "[::1]:3306" {
+					t.Errorf("unexpected address %s", dsn.Addr)
+				}
+			},
+		},
+		{
+			"unix socket",
+			&config.DatabaseDriver{Host: "ignored", Port: "3306", UnixSocket: "/run/mysqld/mysqld.sock"},
+			func(t *testing.T, dsn *mysql.Config) {
+				if dsn.Net != "unix" || dsn.Addr != "/run/mysqld/mysqld.sock" {
+					t.Errorf("unexpected address %s(%s)", dsn.Net, dsn.Addr)
+				}
+			},
+		},
+		{
+			"driver settings",
+			&config.DatabaseDriver{
+				Collation:    "utf8mb4_unicode_ci",
+				Timezone:     "Europe/Berlin",
+				ParseTime:    true,
+				ReadTimeout:  time.Second,
+				WriteTimeout: 2 * time.Second,
+				SslMode:      "preferred",
+			},
+			func(t *testing.T, dsn *mysql.Config) {
+				if dsn.Collation != "utf8mb4_unicode_ci" || !dsn.ParseTime || dsn.TLSConfig != "preferred" {
+					t.Errorf("unexpected settings %+v", dsn)
+				}
+				if dsn.Loc == nil || dsn.Loc.String() != "Europe/Berlin" {
+					t.Errorf("unexpected location %v", dsn.Loc)
+				}
+				if dsn.ReadTimeout != time.Second || dsn.WriteTimeout != 2*time.Second {
+					t.Errorf("unexpected timeouts %v %v", dsn.ReadTimeout, dsn.WriteTimeout)
+				}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dsn, err := NewMysqlConnector(c.config).getConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c.check(t, dsn)
+		})
+	}
+}
+
+func TestMysqlRawDsnIsUsedAsIs(t *testing.T) {
+
+	raw := "user:secret@tcp(db:3306)/app?parseTime=true"
+
+	dsn, err := NewMysqlConnector(&config.DatabaseDriver{Dsn: raw, Host: "ignored"}).getDsn()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dsn != raw {
+		t.Errorf("expected %s, got %s", raw, dsn)
+	}
+}
+
+func TestMysqlTlsConfig(t *testing.T) {
+
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCertificate(t, dir)
+
+	connector := NewMysqlConnector(&config.DatabaseDriver{Host: "db", SslCa: certFile, SslCert: certFile, SslKey: keyFile})
+
+	name, err := connector.getTlsConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if name != connector.getTlsConfigName() || !strings.HasPrefix(name, "custom-") {
+		t.Errorf("unexpected tls config name %s", name)
+	}
+
+	other := NewMysqlConnector(&config.DatabaseDriver{Host: "other", SslCa: certFile})
+	if other.getTlsConfigName() == name {
+		t.Error("different tls settings must register under different names")
+	}
+
+	missing := NewMysqlConnector(&config.DatabaseDriver{SslCa: filepath.Join(dir, "missing.pem")})
+	if _, err := missing.getTlsConfig(); err == nil {
+		t.Error("expected a missing CA file to fail")
+	}
+
+	invalid := filepath.Join(dir, "invalid.pem")
+	if err := os.WriteFile(invalid, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewMysqlConnector(&config.DatabaseDriver{SslCa: invalid}).getTlsConfig(); err == nil {
+		t.Error("expected an invalid CA file to fail")
+	}
+}
+
+func writeTestCertificate(t *testing.T, dir string) (string, string) {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "db"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	files := map[string]*pem.Block{
+		certFile: {Type: "CERTIFICATE", Bytes: der},
+		keyFile:  {Type: "EC PRIVATE KEY", Bytes: keyDer},
+	}
+
+	for file, block := range files {
+		if err := os.WriteFile(file, pem.EncodeToMemory(block), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return certFile, keyFile
+}
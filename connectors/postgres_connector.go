@@ -0,0 +1,69 @@
+package connectors
+
+import (
+	"database/kernel/config"
+	"database/sql"
+	_ "github.com/lib/pq"
+	"strings"
+)
+
+type PostgresConnector struct {
+	*Connector
+	config *config.DatabaseDriver
+}
+
+func NewPostgresConnector(config *config.DatabaseDriver) *PostgresConnector {
+	return &PostgresConnector{
+		config: config,
+		Connector: NewConnector(config),
+	}
+}
+
+func (p *PostgresConnector) Connect() (*sql.DB, error) {
+
+	return p.Connector.CreateConnection("postgres", p.getDsn())
+}
+
+func (p *PostgresConnector) getDsn() string {
+
+	if len(p.config.Dsn) > 0 {
+		return p.config.Dsn
+	}
+
+	params := [][2]string{
+		{"host", p.config.Host},
+		{"port", p.config.Port},
+		{"dbname", p.config.Database},
+		{"user", p.config.Username},
+		{"password", p.config.Password},
+		{"sslmode", p.config.SslMode},
+		{"client_encoding", p.config.Charset},
+		{"search_path", p.config.SearchPath},
+		{"timezone", p.config.Timezone},
+	}
+
+	for _, key := range sortedOptionKeys(p.config.Options) {
+		params = append(params, [2]string{key, p.config.Options[key]})
+	}
+
+	var res []string
+	for _, param := range params {
+		if len(param[1]) > 0 {
+			res = append(res, param[0] + "=" + p.quoteDsnValue(param[1]))
+		}
+	}
+
+	return strings.Join(res, " ")
+}
+
+func (p *PostgresConnector) quoteDsnValue(value string) string {
+
+	if !strings.ContainsAny(value, " '\\") {
+		return value
+	}
+
+	value = strings.Replace(value, "\\", "\\\\", -1)
+	value = strings.Replace(value, "'", "\\'", -1)
+
+	return "'" + value + "'"
+}
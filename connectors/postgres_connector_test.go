@@ -0,0 +1,27 @@
+package connectors
+
+import (
+	"database/kernel/config"
+	"testing"
+)
+
+func TestPostgresDsn(t *testing.T) {
+
+	connector := NewPostgresConnector(&config.DatabaseDriver{
+		Host:       "db",
+		Port:       "5432",
+		Database:   "app",
+		Username:   "postgres",
+		Password:   "it's a secret",
+		SslMode:    "verify-full",
+		SearchPath: "public",
+		Options:    map[string]string{"sslrootcert": "/etc/ssl/ca.pem", "connect_timeout": "5"},
+	})
+
+	expected := "host=db port=5432 dbname=app user=postgres password='it\\'s a secret' sslmode=verify-full " +
+		"search_path=public connect_timeout=5 sslrootcert=/etc/ssl/ca.pem"
+
+	if dsn := connector.getDsn(); dsn != expected {
+		t.Errorf("dsn mismatch\n got: %s\nwant: %s", dsn, expected)
+	}
+}
@@ -0,0 +1,98 @@
+package connectors
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+)
+
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (d *dsnConnector) Connect(ctx context.Context) (driver.Conn, error) {
+
+	return d.driver.Open(d.dsn)
+}
+
+func (d *dsnConnector) Driver() driver.Driver {
+
+	return d.driver
+}
+
+type sessionConnector struct {
+	connector  driver.Connector
+	statements []string
+}
+
+func newSessionConnector(driverName string, dsn string, statements []string) (*sessionConnector, error) {
+
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	drv := db.Driver()
+	db.Close()
+
+	var connector driver.Connector = &dsnConnector{dsn: dsn, driver: drv}
+	if driverContext, ok := drv.(driver.DriverContext); ok {
+		if connector, err = driverContext.OpenConnector(dsn); err != nil {
+			return nil, err
+		}
+	}
+
+	return &sessionConnector{
+		connector:  connector,
+		statements: statements,
+	}, nil
+}
+
+func (s *sessionConnector) Connect(ctx context.Context) (driver.Conn, error) {
+
+	conn, err := s.connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, statement := range s.statements {
+		if err := s.exec(ctx, conn, statement); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+func (s *sessionConnector) Driver() driver.Driver {
+
+	return s.connector.Driver()
+}
+
+func (s *sessionConnector) exec(ctx context.Context, conn driver.Conn, statement string) error {
+
+	if execer, ok := conn.(driver.ExecerContext); ok {
+		_, err := execer.ExecContext(ctx, statement, nil)
+		if err != driver.ErrSkip {
+			return err
+		}
+	}
+
+	stmt, err := conn.Prepare(statement)
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	if stmtExecer, ok := stmt.(driver.StmtExecContext); ok {
+		_, err = stmtExecer.ExecContext(ctx, nil)
+		return err
+	}
+
+	_, err = stmt.Exec(nil)
+
+	return err
+}
@@ -0,0 +1,87 @@
+package connectors
+
+import (
+	"context"
+	"database/internal/fakedb"
+	"database/kernel/config"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestInitStatementsRunOnEveryPooledConnection(t *testing.T) {
+
+	server := fakedb.New()
+	connector := NewConnector(&config.DatabaseDriver{InitStatements: []string{"set a = 1", "set b = 2"}})
+
+	db, err := connector.CreateConnection(fakedb.DriverName, server.DSN())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	ctx := context.Background()
+
+	first, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer first.Close()
+
+	second, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer second.Close()
+
+	if server.Opened() != 2 {
+		t.Fatalf("expected 2 physical connections, got %d", server.Opened())
+	}
+
+	expected := []string{"set a = 1", "set b = 2", "set a = 1", "set b = 2"}
+	if queries := server.Queries(); !reflect.DeepEqual(queries, expected) {
+		t.Errorf("queries mismatch\n got: %q\nwant: %q", queries, expected)
+	}
+}
+
+func TestFailingInitStatementRejectsTheConnection(t *testing.T) {
+
+	server := fakedb.New()
+	server.QueueError(errors.New("unknown variable"))
+
+	connector := NewConnector(&config.DatabaseDriver{InitStatements: []string{"set nope = 1"}})
+
+	db, err := connector.CreateConnection(fakedb.DriverName, server.DSN())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	if err := db.Ping(); err == nil {
+		t.Error("expected the failing init statement to reject the connection")
+	}
+}
+
+func TestConnectionsWithoutInitStatementsRunNothing(t *testing.T) {
+
+	server := fakedb.New()
+
+	db, err := NewConnector(&config.DatabaseDriver{}).CreateConnection(fakedb.DriverName, server.DSN())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	if err := db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(server.Queries()) > 0 {
+		t.Errorf("expected no statements, got %q", server.Queries())
+	}
+}
@@ -0,0 +1,67 @@
+package connectors
+
+import (
+	"database/kernel/config"
+	"database/sql"
+	"fmt"
+	_ "github.com/mattn/go-sqlite3"
+	"net/url"
+	"strings"
+	"sync/atomic"
+)
+
+var memoryDatabaseId uint64
+
+type SqliteConnector struct {
+	*Connector
+	config *config.DatabaseDriver
+}
+
+func NewSqliteConnector(config *config.DatabaseDriver) *SqliteConnector {
+	return &SqliteConnector{
+		config: config,
+		Connector: NewConnector(config),
+	}
+}
+
+func (s *SqliteConnector) Connect() (*sql.DB, error) {
+
+	return s.Connector.CreateConnection("sqlite3", s.getDsn())
+}
+
+func (s *SqliteConnector) getDsn() string {
+
+	if len(s.config.Dsn) > 0 {
+		return s.config.Dsn
+	}
+
+	var params []string
+
+	path := s.config.Database
+	if path == ":memory:" {
+		path = fmt.Sprintf("memory%d", atomic.AddUint64(&memoryDatabaseId, 1))
+		params = append(params, "mode=memory", "cache=shared")
+	}
+
+	if s.config.ForeignKeys == true {
+		params = append(params, "_foreign_keys=1")
+	}
+
+	if len(s.config.JournalMode) > 0 {
+		params = append(params, "_journal_mode=" + s.config.JournalMode)
+	}
+
+	if s.config.BusyTimeout > 0 {
+		params = append(params, fmt.Sprintf("_busy_timeout=%d", s.config.BusyTimeout))
+	}
+
+	for _, key := range sortedOptionKeys(s.config.Options) {
+		params = append(params, url.QueryEscape(key) + "=" + url.QueryEscape(s.config.Options[key]))
+	}
+
+	if len(params) <= 0 {
+		return "file:" + path
+	}
+
+	return "file:" + path + "?" + strings.Join(params, "&")
+}
@@ -0,0 +1,50 @@
+package connectors
+
+import (
+	"database/kernel/config"
+	"strings"
+	"testing"
+)
+
+func TestSqliteDsn(t *testing.T) {
+
+	cases := []struct {
+		name     string
+		config   *config.DatabaseDriver
+		expected string
+	}{
+		{"file", &config.DatabaseDriver{Database: "/tmp/app.db"}, "file:/tmp/app.db"},
+		{"explicit dsn", &config.DatabaseDriver{Dsn: "file:test.db?mode=ro", Database: "ignored"}, "file:test.db?mode=ro"},
+		{
+			"pragmas",
+			&config.DatabaseDriver{Database: "app.db", ForeignKeys: true, JournalMode: "WAL", BusyTimeout: 500},
+			"file:app.db?_foreign_keys=1&_journal_mode=WAL&_busy_timeout=500",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if dsn := NewSqliteConnector(c.config).getDsn(); dsn != c.expected {
+				t.Errorf("dsn mismatch\n got: %s\nwant: %s", dsn, c.expected)
+			}
+		})
+	}
+}
+
+func TestSqliteInMemoryDatabasesAreNotShared(t *testing.T) {
+
+	memory := &config.DatabaseDriver{Database: ":memory:"}
+
+	first := NewSqliteConnector(memory).getDsn()
+	second := NewSqliteConnector(memory).getDsn()
+
+	if first == second {
+		t.Errorf("expected distinct in-memory databases, both use %s", first)
+	}
+
+	for _, dsn := range []string{first, second} {
+		if !strings.HasPrefix(dsn, "file:memory") || !strings.HasSuffix(dsn, "?mode=memory&cache=shared") {
+			t.Errorf("unexpected in-memory dsn %s", dsn)
+		}
+	}
+}
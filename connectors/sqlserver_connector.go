@@ -0,0 +1,53 @@
+package connectors
+
+import (
+	"database/kernel/config"
+	"database/sql"
+	_ "github.com/microsoft/go-mssqldb"
+	"net/url"
+)
+
+type SqlServerConnector struct {
+	*Connector
+	config *config.DatabaseDriver
+}
+
+func NewSqlServerConnector(config *config.DatabaseDriver) *SqlServerConnector {
+	return &SqlServerConnector{
+		config: config,
+		Connector: NewConnector(config),
+	}
+}
+
+func (s *SqlServerConnector) Connect() (*sql.DB, error) {
+
+	return s.Connector.CreateConnection("sqlserver", s.getDsn())
+}
+
+func (s *SqlServerConnector) getDsn() string {
+
+	if len(s.config.Dsn) > 0 {
+		return s.config.Dsn
+	}
+
+	host := s.config.Host
+	if len(s.config.Port) > 0 {
+		host += ":" + s.config.Port
+	}
+
+	query := url.Values{}
+	query.Add("database", s.config.Database)
+
+	for key, value := range s.config.Options {
+		query.Set(key, value)
+	}
+
+	dsn := url.URL{
+		Scheme: "sqlserver",
+		User: url.UserPassword(s.config.Username, s.config.Password),
+		Host: host,
+		RawQuery: query.Encode(),
+	}
+
+	return dsn.String()
+}
@@ -0,0 +1,24 @@
+package connectors
+
+import (
+	"database/kernel/config"
+	"testing"
+)
+
+func TestSqlServerDsn(t *testing.T) {
+
+	connector := NewSqlServerConnector(&config.DatabaseDriver{
+		Host:     "db",
+		Port:     "1433",
+		Database: "app",
+		Username: "sa",
+		Password: "p@ss/word",
+		Options:  map[string]string{"encrypt": "true"},
+	})
+
+	expected := "sqlserver://sa:p%40ss%2Fword@db:1433?database=app&encrypt=true"
+
+	if dsn := connector.getDsn(); dsn != expected {
+		t.Errorf("dsn mismatch\n got: %s\nwant: %s", dsn, expected)
+	}
+}
@@ -1,6 +1,10 @@
 package contracts
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+	"time"
+)
 
 type TransactionConnection interface {
 	Connection
@@ -11,21 +15,77 @@ type Connection interface {
 
 	GetPDO() *sql.DB
 
+	GetReadPDO() *sql.DB
+
+	Stats() sql.DBStats
+
+	ReadStats() []sql.DBStats
+
+	Ping(ctx context.Context) error
+
+	Reconnect() error
+
+	Disconnect() error
+
 	GetGrammar() Grammar
 
+	GetSchemaGrammar() SchemaGrammar
+
+	Schema() SchemaBuilder
+
 	Query() QueryBuilder
 
 	Table(table string) QueryBuilder
 
 	Select(query string, bindings []interface{}) (*sql.Rows, error)
 
-	Insert(query string, bindings []interface{}) sql.Result
+	SelectContext(ctx context.Context, query string, bindings []interface{}) (*sql.Rows, error)
+
+	SelectFromWriteConnection(query string, bindings []interface{}) (*sql.Rows, error)
+
+	SelectFromWriteConnectionContext(ctx context.Context, query string, bindings []interface{}) (*sql.Rows, error)
+
+	MarkRecordsModified(ctx context.Context)
+
+	Insert(query string, bindings []interface{}) (sql.Result, error)
+
+	InsertContext(ctx context.Context, query string, bindings []interface{}) (sql.Result, error)
+
+	Update(query string, bindings []interface{}) (int64, error)
+
+	UpdateContext(ctx context.Context, query string, bindings []interface{}) (int64, error)
+
+	Delete(query string, bindings []interface{}) (int64, error)
+
+	DeleteContext(ctx context.Context, query string, bindings []interface{}) (int64, error)
+
+	Transaction(ctx context.Context, callback func(tc TransactionConnection) error, attempts int) error
+
+	TransactionWithOptions(
+		ctx context.Context, opts *sql.TxOptions, callback func(tc TransactionConnection) error, attempts int,
+	) error
+
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (TransactionConnection, error)
+
+	Listen(listener QueryListener)
+
+	EnableQueryLog()
+
+	DisableQueryLog()
+
+	GetQueryLog() []QueryEvent
+
+	FlushQueryLog()
+
+	WhenQueryingForLongerThan(threshold time.Duration, callback func(event QueryEvent))
+
+	OnBegin(listener func(level int))
 
-	Update(query string, bindings []interface{}) int64
+	OnCommit(listener func(level int))
 
-	Delete(query string, bindings []interface{}) int64
+	OnRollback(listener func(level int))
 
-	Transaction(args ...interface{}) TransactionConnection
+	Statement(sql string, bindings []interface{}) (sql.Result, error)
 
-	Statement(sql string, bindings []interface{}) sql.Result
+	StatementContext(ctx context.Context, sql string, bindings []interface{}) (sql.Result, error)
 }
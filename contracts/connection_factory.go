@@ -4,5 +4,5 @@ import "database/kernel/config"
 
 type ConnectionFactory interface {
 
-	Make(config *config.DatabaseDriver) Connection
+	Make(config *config.DatabaseDriver) (Connection, error)
 }
\ No newline at end of file
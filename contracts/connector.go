@@ -4,5 +4,5 @@ import "database/sql"
 
 type Connector interface {
 
-	Connect() *sql.DB
+	Connect() (*sql.DB, error)
 }
\ No newline at end of file
@@ -0,0 +1,12 @@
+package contracts
+
+type Cursor interface {
+
+	Next() bool
+
+	Scan(dest interface{}) error
+
+	Err() error
+
+	Close() error
+}
\ No newline at end of file
@@ -1,23 +1,51 @@
 package contracts
 
+import "database/sql"
+
 type Grammar interface {
 
 	CompileSelect(b QueryBuilder) string
 
 	CompileInsert(b QueryBuilder, values []map[string]interface{}, columns []string) string
 
+	CompileInsertGetId(b QueryBuilder, values []map[string]interface{}, columns []string, sequence string) string
+
+	SupportsReturning() bool
+
+	SupportsInsertOrIgnore() bool
+
+	SupportsLockModifiers() bool
+
+	CompileInsertOrIgnore(b QueryBuilder, values []map[string]interface{}, columns []string) string
+
+	CompileUpsert(b QueryBuilder, values []map[string]interface{}, columns []string, uniqueBy []string, update []string) string
+
+	CompileInsertUsing(b QueryBuilder, columns []string, sql string) string
+
 	CompileUpdate(b QueryBuilder, values map[string]interface{}) string
 
 	CompileDelete(b QueryBuilder) string
 
 	CompileTruncate(b QueryBuilder) string
 
+	CompileSetTransaction(opts *sql.TxOptions) string
+
+	SupportsTransactionOptions() bool
+
+	SupportsReadOnlyTransactions() bool
+
 	CompileSavepoint(name string) string
 
 	CompileSavepointRollback(name string) string
 
 	Wrap(v string) string
 
+	SubstituteParameters(sql string) string
+
+	SubstituteBindingsIntoRawSql(sql string, bindings []interface{}) string
+
+	EscapeValue(value interface{}) string
+
 	PrepareBindingsForUpdate(b QueryBuilder, bindings map[string][]interface{}, values map[string]interface{}) []interface{}
 
 	PrepareBindingsForDelete(b QueryBuilder, bindings map[string][]interface{}) []interface{}
@@ -1,6 +1,27 @@
 package contracts
 
+import (
+	"context"
+	"database/sql"
+)
+
 type Manager interface {
 
-	Connection(name string) Connection
+	Connection(name string) (Connection, error)
+
+	Connections() map[string]Connection
+
+	SetDefaultConnection(name string)
+
+	Stats() map[string]sql.DBStats
+
+	Ping(ctx context.Context) error
+
+	Reconnect(name string) (Connection, error)
+
+	Disconnect(name string) error
+
+	Purge(name string) error
+
+	Close() error
 }
\ No newline at end of file
@@ -0,0 +1,8 @@
+package contracts
+
+type Migration interface {
+
+	Up(connection Connection) error
+
+	Down(connection Connection) error
+}
\ No newline at end of file
@@ -1,13 +1,52 @@
 package contracts
 
-import "database/sql"
+import (
+	"context"
+	"database/pagination"
+	"database/sql"
+	"io"
+)
 
 type QueryBuilder interface {
 
 	ToSql() string
 
+	ToRawSql() string
+
+	ToRawInsertSql(values ...map[string]interface{}) string
+
+	ToRawUpdateSql(values map[string]interface{}) string
+
+	ToRawDeleteSql() string
+
+	Dump(w io.Writer) QueryBuilder
+
+	WithContext(ctx context.Context) QueryBuilder
+
+	UseWritePdo() QueryBuilder
+
 	Get() (*sql.Rows, error)
 
+	Scan(dest interface{}) error
+
+	First(dest interface{}) error
+
+	Pluck(column string, dest interface{}) error
+
+	Value(column string) (interface{}, error)
+
+	Chunk(size int, callback func(rows []map[string]interface{}) error) error
+
+	ChunkById(size int, column string, callback func(rows []map[string]interface{}) error) error
+
+	Cursor() (Cursor, error)
+
+	Paginate(perPage int, page int) (*pagination.Paginator, error)
+
+	SimplePaginate(perPage int, page int) (*pagination.SimplePaginator, error)
+
+	CursorPaginate(perPage int, cursor string) (*pagination.CursorPaginator, error)
+
 	Limit(n int) QueryBuilder
 
 	Offset(n int) QueryBuilder
@@ -24,6 +63,8 @@ type QueryBuilder interface {
 
 	SelectSub(query interface{}, as string) QueryBuilder
 
+	Distinct() QueryBuilder
+
 	Join(table string, args ...interface{}) QueryBuilder
 
 	JoinWhere(table string, args ...interface{}) QueryBuilder
@@ -116,27 +157,47 @@ type QueryBuilder interface {
 
 	OrderByRaw(sql string, bindings ...interface{}) QueryBuilder
 
+	LockForUpdate() QueryBuilder
+
+	SharedLock() QueryBuilder
+
+	Lock(raw string) QueryBuilder
+
+	SkipLocked() QueryBuilder
+
+	NoWait() QueryBuilder
+
 	Union(query interface{}) QueryBuilder
 
 	UnionAll(query interface{}) QueryBuilder
 
-	Count(column string) (*sql.Rows, error)
+	Count(columns ...string) (int64, error)
+
+	Min(column string) (interface{}, error)
+
+	Max(column string) (interface{}, error)
+
+	Sum(column string) (float64, error)
+
+	Avg(column string) (sql.NullFloat64, error)
+
+	Insert(values ...map[string]interface{}) (sql.Result, error)
 
-	Min(column string) (*sql.Rows, error)
+	InsertGetId(values map[string]interface{}, sequence string) (int64, error)
 
-	Max(column string) (*sql.Rows, error)
+	InsertGetIds(values []map[string]interface{}, sequence string) ([]int64, error)
 
-	Sum(column string) (*sql.Rows, error)
+	InsertOrIgnore(values ...map[string]interface{}) (int64, error)
 
-	Avg(column string) (*sql.Rows, error)
+	Upsert(values []map[string]interface{}, uniqueBy []string, update []string) (int64, error)
 
-	Insert(values ...map[string]interface{}) sql.Result
+	InsertUsing(columns []string, query interface{}) (int64, error)
 
-	Update(values map[string]interface{}) int64
+	Update(values map[string]interface{}) (int64, error)
 
-	Delete() int64
+	Delete() (int64, error)
 
-	Truncate() sql.Result
+	Truncate() (sql.Result, error)
 
 	GetBindingsForSql(except ...string) []interface{}
 }
@@ -0,0 +1,22 @@
+package contracts
+
+import "time"
+
+type QueryEvent struct {
+	Sql          string
+	Bindings     []interface{}
+	Time         time.Duration
+	RowsAffected int64
+	Err          error
+}
+
+type QueryListener interface {
+
+	Handle(event QueryEvent)
+}
+
+type QueryListenerFunc func(event QueryEvent)
+
+func (f QueryListenerFunc) Handle(event QueryEvent) {
+	f(event)
+}
\ No newline at end of file
@@ -0,0 +1,85 @@
+package contracts
+
+type SchemaBuilder interface {
+
+	Create(table string, callback func(table Blueprint)) error
+
+	Table(table string, callback func(table Blueprint)) error
+
+	Drop(table string) error
+
+	DropIfExists(table string) error
+
+	HasTable(table string) (bool, error)
+
+	HasColumn(table string, column string) (bool, error)
+}
+
+type Blueprint interface {
+
+	GetTable() string
+
+	Increments(column string) ColumnDefinition
+
+	BigIncrements(column string) ColumnDefinition
+
+	String(column string, length ...int) ColumnDefinition
+
+	Text(column string) ColumnDefinition
+
+	Integer(column string) ColumnDefinition
+
+	BigInteger(column string) ColumnDefinition
+
+	Boolean(column string) ColumnDefinition
+
+	Decimal(column string, total int, places int) ColumnDefinition
+
+	Json(column string) ColumnDefinition
+
+	Timestamp(column string) ColumnDefinition
+
+	Timestamps()
+
+	Primary(columns ...string)
+
+	Index(columns ...string)
+
+	Unique(columns ...string)
+
+	Foreign(columns ...string) ForeignKeyDefinition
+
+	DropColumn(columns ...string)
+
+	RenameColumn(from string, to string)
+
+	DropIndex(name string)
+
+	DropUnique(name string)
+
+	DropForeign(name string)
+}
+
+type ColumnDefinition interface {
+
+	Nullable() ColumnDefinition
+
+	Default(value interface{}) ColumnDefinition
+
+	Unsigned() ColumnDefinition
+
+	Comment(comment string) ColumnDefinition
+
+	After(column string) ColumnDefinition
+}
+
+type ForeignKeyDefinition interface {
+
+	References(columns ...string) ForeignKeyDefinition
+
+	On(table string) ForeignKeyDefinition
+
+	OnDelete(action string) ForeignKeyDefinition
+
+	OnUpdate(action string) ForeignKeyDefinition
+}
\ No newline at end of file
@@ -0,0 +1,12 @@
+package contracts
+
+type SchemaGrammar interface {
+
+	CompileBlueprint(b Blueprint) []string
+
+	CompileTableExists() string
+
+	CompileColumnExists() string
+
+	SupportsSchemaTransactions() bool
+}
\ No newline at end of file
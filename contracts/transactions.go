@@ -1,16 +1,29 @@
 package contracts
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+)
 
 type Transactable interface {
 
 	GetTxPDO() *sql.Tx
 
-	BeginTransaction()
+	BeginTransaction() error
 
-	Commit()
+	BeginTransactionContext(ctx context.Context, opts *sql.TxOptions) error
 
-	RollBack(level interface{})
+	Commit() error
+
+	RollBack(level interface{}) error
 
 	TransactionLevel() int
+
+	OnBegin(listener func(level int))
+
+	OnCommit(listener func(level int))
+
+	OnRollback(listener func(level int))
+
+	AfterCommit(callback func())
 }
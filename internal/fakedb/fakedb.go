@@ -0,0 +1,320 @@
+package fakedb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+const DriverName = "fakedb"
+
+var (
+	registerOnce sync.Once
+	serverId     uint64
+	servers      sync.Map
+)
+
+type Call struct {
+	Query string
+	Args  []driver.Value
+}
+
+type Response struct {
+	Columns      []string
+	Rows         [][]driver.Value
+	RowsAffected int64
+	LastInsertId int64
+	Err          error
+}
+
+type Server struct {
+	mu         sync.Mutex
+	dsn        string
+	calls      []Call
+	responses  []Response
+	commitErrs []error
+	txOptions  []driver.TxOptions
+	opened     int
+}
+
+func New() *Server {
+
+	registerOnce.Do(func() {
+		sql.Register(DriverName, &fakeDriver{})
+	})
+
+	server := &Server{dsn: "server-" + strconv.FormatUint(atomic.AddUint64(&serverId, 1), 10)}
+	servers.Store(server.dsn, server)
+
+	return server
+}
+
+func (s *Server) DSN() string {
+
+	return s.dsn
+}
+
+func (s *Server) Open() *sql.DB {
+
+	db, err := sql.Open(DriverName, s.dsn)
+	if err != nil {
+		panic(err)
+	}
+
+	return db
+}
+
+func (s *Server) QueueRows(columns []string, rows ...[]driver.Value) {
+
+	s.queue(Response{Columns: columns, Rows: rows})
+}
+
+func (s *Server) QueueResult(rowsAffected int64, lastInsertId int64) {
+
+	s.queue(Response{RowsAffected: rowsAffected, LastInsertId: lastInsertId})
+}
+
+func (s *Server) QueueError(err error) {
+
+	s.queue(Response{Err: err})
+}
+
+func (s *Server) QueueCommitError(err error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.commitErrs = append(s.commitErrs, err)
+}
+
+func (s *Server) Calls() []Call {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Call{}, s.calls...)
+}
+
+func (s *Server) Queries() []string {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queries := make([]string, 0, len(s.calls))
+	for _, call := range s.calls {
+		queries = append(queries, call.Query)
+	}
+
+	return queries
+}
+
+func (s *Server) TxOptions() []driver.TxOptions {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]driver.TxOptions{}, s.txOptions...)
+}
+
+func (s *Server) Opened() int {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.opened
+}
+
+func (s *Server) queue(response Response) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses = append(s.responses, response)
+}
+
+func (s *Server) record(query string, args []driver.Value) Response {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, Call{Query: query, Args: args})
+
+	if len(s.responses) <= 0 {
+		return Response{}
+	}
+
+	response := s.responses[0]
+	s.responses = s.responses[1:]
+
+	return response
+}
+
+func (s *Server) commit() error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, Call{Query: "COMMIT"})
+
+	if len(s.commitErrs) <= 0 {
+		return nil
+	}
+
+	err := s.commitErrs[0]
+	s.commitErrs = s.commitErrs[1:]
+
+	return err
+}
+
+type fakeDriver struct{}
+
+func (d *fakeDriver) Open(dsn string) (driver.Conn, error) {
+
+	server, ok := servers.Load(dsn)
+	if !ok {
+		return nil, errors.New("Unknown fake server " + dsn)
+	}
+
+	s := server.(*Server)
+
+	s.mu.Lock()
+	s.opened++
+	s.mu.Unlock()
+
+	return &conn{server: s}, nil
+}
+
+type conn struct {
+	server *Server
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+
+	return &stmt{server: c.server, query: query}, nil
+}
+
+func (c *conn) Close() error {
+
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+
+	c.server.mu.Lock()
+	defer c.server.mu.Unlock()
+
+	c.server.txOptions = append(c.server.txOptions, opts)
+	c.server.calls = append(c.server.calls, Call{Query: "BEGIN"})
+
+	return &tx{server: c.server}, nil
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+
+	return nil
+}
+
+type tx struct {
+	server *Server
+}
+
+func (t *tx) Commit() error {
+
+	return t.server.commit()
+}
+
+func (t *tx) Rollback() error {
+
+	t.server.mu.Lock()
+	defer t.server.mu.Unlock()
+
+	t.server.calls = append(t.server.calls, Call{Query: "ROLLBACK"})
+
+	return nil
+}
+
+type stmt struct {
+	server *Server
+	query  string
+}
+
+func (s *stmt) Close() error {
+
+	return nil
+}
+
+func (s *stmt) NumInput() int {
+
+	return -1
+}
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+
+	response := s.server.record(s.query, args)
+	if response.Err != nil {
+		return nil, response.Err
+	}
+
+	return result{response}, nil
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+
+	response := s.server.record(s.query, args)
+	if response.Err != nil {
+		return nil, response.Err
+	}
+
+	return &rows{response: response}, nil
+}
+
+type result struct {
+	response Response
+}
+
+func (r result) LastInsertId() (int64, error) {
+
+	return r.response.LastInsertId, nil
+}
+
+func (r result) RowsAffected() (int64, error) {
+
+	return r.response.RowsAffected, nil
+}
+
+type rows struct {
+	response Response
+	position int
+}
+
+func (r *rows) Columns() []string {
+
+	return r.response.Columns
+}
+
+func (r *rows) Close() error {
+
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+
+	if r.position >= len(r.response.Rows) {
+		return io.EOF
+	}
+
+	copy(dest, r.response.Rows[r.position])
+	r.position++
+
+	return nil
+}
@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 type DatabaseConfig struct {
 	Default string
 	Connections map[string]DatabaseDriver
@@ -7,15 +9,51 @@ type DatabaseConfig struct {
 
 type DatabaseDriver struct {
 	Driver string
+	Url string
+	Dsn string
 	Host string
 	Port string
 	Database string
 	Password string
 	Username string
+	UnixSocket string
+
+	Read []string
+	Write []string
+	Sticky bool
+	ReadStrategy string
+
+	MaxOpenConns int
+	MaxIdleConns int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
 
 	Strict bool
 	Timezone string
 
 	Charset string
 	Collation string
+
+	SslCa string
+	SslCert string
+	SslKey string
+
+	ParseTime bool
+	ReadTimeout time.Duration
+	WriteTimeout time.Duration
+
+	Options map[string]string
+
+	InitStatements []string
+
+	UpsertAlias bool
+
+	Isolation string
+
+	SslMode string
+	SearchPath string
+
+	ForeignKeys bool
+	JournalMode string
+	BusyTimeout int
 }
\ No newline at end of file
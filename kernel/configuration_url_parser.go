@@ -0,0 +1,80 @@
+package kernel
+
+import (
+	"database/kernel/config"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+var driverAliases = map[string]string{
+	"mariadb":    "mysql",
+	"mysql2":     "mysql",
+	"postgres":   "pgsql",
+	"postgresql": "pgsql",
+	"sqlite3":    "sqlite",
+	"mssql":      "sqlsrv",
+	"sqlserver":  "sqlsrv",
+}
+
+func parseConfigurationUrl(driverConfig *config.DatabaseDriver) (*config.DatabaseDriver, error) {
+
+	if len(driverConfig.Url) <= 0 {
+		return driverConfig, nil
+	}
+
+	u, err := url.Parse(driverConfig.Url)
+	if err != nil {
+		return nil, errors.New("Invalid database connection url")
+	}
+
+	parsed := *driverConfig
+
+	if len(u.Scheme) > 0 {
+		parsed.Driver = strings.ToLower(u.Scheme)
+		if alias, ok := driverAliases[parsed.Driver]; ok {
+			parsed.Driver = alias
+		}
+	}
+
+	if host := u.Hostname(); len(host) > 0 {
+		parsed.Host = host
+	}
+
+	if port := u.Port(); len(port) > 0 {
+		parsed.Port = port
+	}
+
+	if u.User != nil {
+		parsed.Username = u.User.Username()
+		if password, ok := u.User.Password(); ok {
+			parsed.Password = password
+		}
+	}
+
+	if database := parseUrlDatabase(parsed.Driver, u); len(database) > 0 {
+		parsed.Database = database
+	}
+
+	if query := u.Query(); len(query) > 0 {
+		parsed.Options = make(map[string]string, len(driverConfig.Options)+len(query))
+		for key, value := range driverConfig.Options {
+			parsed.Options[key] = value
+		}
+
+		for key := range query {
+			parsed.Options[key] = query.Get(key)
+		}
+	}
+
+	return &parsed, nil
+}
+
+func parseUrlDatabase(driver string, u *url.URL) string {
+
+	if driver == "sqlite" && len(u.Host) <= 0 && u.Path != "/:memory:" {
+		return u.Path
+	}
+
+	return strings.TrimPrefix(u.Path, "/")
+}
@@ -0,0 +1,77 @@
+package kernel
+
+import (
+	"database/kernel/config"
+	"reflect"
+	"testing"
+)
+
+func TestParseConfigurationUrl(t *testing.T) {
+
+	cases := []struct {
+		name     string
+		config   *config.DatabaseDriver
+		expected config.DatabaseDriver
+	}{
+		{
+			"mysql with escaped password",
+			&config.DatabaseDriver{Url: "mysql://root:p%40ss%2Fword@db:3307/app?charset=utf8mb4", Options: map[string]string{"tls": "true"}},
+			config.DatabaseDriver{
+				Driver:   "mysql",
+				Host:     "db",
+				Port:     "3307",
+				Database: "app",
+				Username: "root",
+				Password: "p@ss/word",
+				Options:  map[string]string{"tls": "true", "charset": "utf8mb4"},
+			},
+		},
+		{
+			"postgres alias",
+			&config.DatabaseDriver{Url: "postgresql://user@[::1]:5432/app"},
+			config.DatabaseDriver{Driver: "pgsql", Host: "::1", Port: "5432", Database: "app", Username: "user"},
+		},
+		{
+			"sqlite file",
+			&config.DatabaseDriver{Url: "sqlite:///var/data/app.db"},
+			config.DatabaseDriver{Driver: "sqlite", Database: "/var/data/app.db"},
+		},
+		{
+			"sqlite memory",
+			&config.DatabaseDriver{Url: "sqlite:///:memory:"},
+			config.DatabaseDriver{Driver: "sqlite", Database: ":memory:"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parsed, err := parseConfigurationUrl(c.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			parsed.Url = ""
+			if !reflect.DeepEqual(*parsed, c.expected) {
+				t.Errorf("config mismatch\n got: %+v\nwant: %+v", *parsed, c.expected)
+			}
+		})
+	}
+}
+
+func TestParseConfigurationUrlKeepsConfigWithoutUrl(t *testing.T) {
+
+	driver := &config.DatabaseDriver{Driver: "mysql", Host: "db"}
+
+	parsed, err := parseConfigurationUrl(driver)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if parsed != driver {
+		t.Error("expected the config to be returned unchanged")
+	}
+
+	if _, err := parseConfigurationUrl(&config.DatabaseDriver{Url: "mysql://db:port/app"}); err == nil {
+		t.Error("expected an invalid url to fail")
+	}
+}
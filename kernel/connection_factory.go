@@ -1,47 +1,152 @@
 package kernel
 
 import (
+	"database/concerns"
 	"database/connections"
 	"database/connectors"
 	"database/contracts"
 	"database/kernel/config"
 	"database/sql"
+	"errors"
+	"math/rand"
+	"net"
 )
 
+type readWriteConnection interface {
+
+	SetReadPDOs(pdos []*sql.DB)
+}
+
 type ConnectionFactory struct {
 
 }
 
-func (c *ConnectionFactory) Make(config *config.DatabaseDriver) contracts.Connection {
+func (c *ConnectionFactory) Make(config *config.DatabaseDriver) (contracts.Connection, error) {
 
 	return c.newConnection(config)
 }
 
-func (c *ConnectionFactory) newConnection(config *config.DatabaseDriver) contracts.Connection {
+func (c *ConnectionFactory) newConnection(config *config.DatabaseDriver) (contracts.Connection, error) {
 
-	pdo := c.createConnector(config).Connect()
+	config, err := parseConfigurationUrl(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := concerns.ParseIsolationLevel(config.Isolation); err != nil {
+		return nil, err
+	}
+
+	if len(config.Read) > 0 || len(config.Write) > 0 {
+		return c.createReadWriteConnection(config)
+	}
+
+	pdo, err := c.createPdo(config)
+	if err != nil {
+		return nil, err
+	}
 
 	return c.createConnection(config, pdo)
 }
 
-func (c *ConnectionFactory) createConnector(config *config.DatabaseDriver) contracts.Connector {
+func (c *ConnectionFactory) createReadWriteConnection(config *config.DatabaseDriver) (contracts.Connection, error) {
+
+	writeConfig := config
+	if len(config.Write) > 0 {
+		writeConfig = c.configWithHost(config, config.Write[rand.Intn(len(config.Write))])
+	}
+
+	pdo, err := c.createPdo(writeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var readPdos []*sql.DB
+	for _, host := range config.Read {
+		readPdo, err := c.createPdo(c.configWithHost(config, host))
+		if err != nil {
+			pdo.Close()
+			for _, opened := range readPdos {
+				opened.Close()
+			}
+
+			return nil, err
+		}
+
+		readPdos = append(readPdos, readPdo)
+	}
+
+	connection, err := c.createConnection(config, pdo)
+	if err != nil {
+		return nil, err
+	}
+
+	if rw, ok := connection.(readWriteConnection); ok {
+		rw.SetReadPDOs(readPdos)
+	}
+
+	return connection, nil
+}
+
+func (c *ConnectionFactory) configWithHost(config *config.DatabaseDriver, host string) *config.DatabaseDriver {
+
+	hostConfig := *config
+	hostConfig.Host = host
+
+	if h, port, err := net.SplitHostPort(host); err == nil {
+		hostConfig.Host = h
+		hostConfig.Port = port
+	}
+
+	return &hostConfig
+}
+
+func (c *ConnectionFactory) createPdo(config *config.DatabaseDriver) (*sql.DB, error) {
+
+	connector, err := c.createConnector(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return connector.Connect()
+}
+
+func (c *ConnectionFactory) createConnector(config *config.DatabaseDriver) (contracts.Connector, error) {
 
 	switch config.Driver {
 		case "mysql":
-			return connectors.NewMysqlConnector(config)
+			return connectors.NewMysqlConnector(config), nil
+
+		case "pgsql":
+			return connectors.NewPostgresConnector(config), nil
+
+		case "sqlite":
+			return connectors.NewSqliteConnector(config), nil
+
+		case "sqlsrv":
+			return connectors.NewSqlServerConnector(config), nil
 
 		default:
-			panic("Unresolved database connector driver")
+			return nil, errors.New("Unresolved database connector driver")
 	}
 }
 
-func (c *ConnectionFactory) createConnection(config *config.DatabaseDriver, pdo *sql.DB) contracts.Connection {
+func (c *ConnectionFactory) createConnection(config *config.DatabaseDriver, pdo *sql.DB) (contracts.Connection, error) {
 
 	switch config.Driver {
 		case "mysql":
-			return connections.NewMysqlConnection(pdo, config)
+			return connections.NewMysqlConnection(pdo, config), nil
+
+		case "pgsql":
+			return connections.NewPostgresConnection(pdo, config), nil
+
+		case "sqlite":
+			return connections.NewSqliteConnection(pdo, config), nil
+
+		case "sqlsrv":
+			return connections.NewSqlServerConnection(pdo, config), nil
 
 		default:
-			panic("Unresolved database connection driver")
+			return nil, errors.New("Unresolved database connection driver")
 	}
 }
\ No newline at end of file
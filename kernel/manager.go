@@ -1,52 +1,296 @@
 package kernel
 
 import (
+	"context"
 	"database/contracts"
 	"database/kernel/config"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
 )
 
+type reconnectableConnection interface {
+
+	GetReadPDOs() []*sql.DB
+
+	SetPDOs(pdo *sql.DB, readPdos []*sql.DB) error
+
+	SetReconnector(reconnector func() error)
+}
+
+type pendingConnection struct {
+	done chan struct{}
+	connection contracts.Connection
+	err error
+}
+
 type Manager struct {
+	mu sync.RWMutex
 	config *config.DatabaseConfig
 	factory contracts.ConnectionFactory
 	connections map[string]contracts.Connection
+	pending map[string]*pendingConnection
+}
+
+func (m *Manager) Connection(name string) (contracts.Connection, error) {
+
+	if len(name) <= 0 {
+
+		name = m.getDefaultDriver()
+	}
+
+	m.mu.Lock()
+
+	if connection, hasConnection := m.connections[name]; hasConnection {
+
+		m.mu.Unlock()
+
+		return connection, nil
+	}
+
+	if pending, isPending := m.pending[name]; isPending {
+
+		m.mu.Unlock()
+
+		<-pending.done
+
+		return pending.connection, pending.err
+	}
+
+	pending := &pendingConnection{done: make(chan struct{})}
+	m.pending[name] = pending
+
+	m.mu.Unlock()
+
+	pending.connection, pending.err = m.makeConnection(name)
+
+	m.mu.Lock()
+
+	if pending.err == nil {
+
+		pending.connection = m.configureConnection(pending.connection, name)
+		m.connections[name] = pending.connection
+	}
+
+	delete(m.pending, name)
+
+	m.mu.Unlock()
+
+	close(pending.done)
+
+	return pending.connection, pending.err
+}
+
+func (m *Manager) Connections() map[string]contracts.Connection {
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	connections := make(map[string]contracts.Connection, len(m.connections))
+	for name, connection := range m.connections {
+		connections[name] = connection
+	}
+
+	return connections
+}
+
+func (m *Manager) SetDefaultConnection(name string) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.config.Default = name
+}
+
+func (m *Manager) Ping(ctx context.Context) error {
+
+	connections := m.Connections()
+
+	names := make([]string, 0, len(connections))
+	for name := range connections {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := connections[name].Ping(ctx); err != nil {
+			return fmt.Errorf("Error: ping failed for %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) Reconnect(name string) (contracts.Connection, error) {
+
+	if len(name) <= 0 {
+
+		name = m.getDefaultDriver()
+	}
+
+	connection, hasConnection := m.resolved(name)
+
+	if ! hasConnection {
+
+		return m.Connection(name)
+	}
+
+	return m.refreshPdoConnections(name, connection)
 }
 
-func (m *Manager) Connection(name string) contracts.Connection {
+func (m *Manager) Disconnect(name string) error {
 
 	if len(name) <= 0 {
 
 		name = m.getDefaultDriver()
 	}
 
-	_, hasConnection := m.connections[name]
+	connection, hasConnection := m.resolved(name)
 
 	if ! hasConnection {
 
-		m.connections[name] = m.makeConnection(name)
+		return nil
+	}
+
+	return connection.Disconnect()
+}
+
+func (m *Manager) Purge(name string) error {
+
+	if len(name) <= 0 {
+
+		name = m.getDefaultDriver()
+	}
+
+	m.mu.Lock()
+
+	connection, hasConnection := m.connections[name]
+	delete(m.connections, name)
+
+	m.mu.Unlock()
+
+	if ! hasConnection {
+
+		return nil
+	}
+
+	return m.release(connection)
+}
+
+func (m *Manager) Close() error {
+
+	m.mu.Lock()
+
+	connections := m.connections
+	m.connections = make(map[string]contracts.Connection)
+
+	m.mu.Unlock()
+
+	var err error
+	for name, connection := range connections {
+		if disconnectErr := m.release(connection); disconnectErr != nil && err == nil {
+			err = fmt.Errorf("Error: close failed for %s: %w", name, disconnectErr)
+		}
+	}
+
+	return err
+}
+
+func (m *Manager) Stats() map[string]sql.DBStats {
+
+	connections := m.Connections()
+
+	stats := make(map[string]sql.DBStats, len(connections))
+	for name, connection := range connections {
+		stats[name] = connection.Stats()
 	}
 
-	return m.connections[name]
+	return stats
 }
 
 func (m *Manager) getDefaultDriver() string {
 
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	return m.config.Default;
 }
 
-func (m *Manager) makeConnection(name string) contracts.Connection {
+func (m *Manager) resolved(name string) (contracts.Connection, bool) {
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	connection, hasConnection := m.connections[name]
+
+	return connection, hasConnection
+}
+
+func (m *Manager) configureConnection(connection contracts.Connection, name string) contracts.Connection {
+
+	if reconnectable, ok := connection.(reconnectableConnection); ok {
+		reconnectable.SetReconnector(func() error {
+			_, err := m.refreshPdoConnections(name, connection)
+			return err
+		})
+	}
+
+	return connection
+}
+
+func (m *Manager) release(connection contracts.Connection) error {
+
+	if reconnectable, ok := connection.(reconnectableConnection); ok {
+		reconnectable.SetReconnector(nil)
+	}
 
-	configDriver := m.configuration(name)
+	return connection.Disconnect()
+}
+
+func (m *Manager) refreshPdoConnections(name string, connection contracts.Connection) (contracts.Connection, error) {
+
+	reconnectable, ok := connection.(reconnectableConnection)
+	if ! ok {
+		return nil, errors.New("Error: connection " + name + " does not support reconnecting")
+	}
+
+	fresh, err := m.makeConnection(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var readPdos []*sql.DB
+	if freshReconnectable, ok := fresh.(reconnectableConnection); ok {
+		readPdos = freshReconnectable.GetReadPDOs()
+	}
+
+	if err := reconnectable.SetPDOs(fresh.GetPDO(), readPdos); err != nil {
+		return nil, fmt.Errorf("Error: reconnect failed for %s: %w", name, err)
+	}
+
+	return connection, nil
+}
+
+func (m *Manager) makeConnection(name string) (contracts.Connection, error) {
+
+	configDriver, err := m.configuration(name)
+	if err != nil {
+		return nil, err
+	}
 
 	return m.factory.Make(configDriver)
 }
 
-func (m *Manager) configuration(name string) *config.DatabaseDriver {
+func (m *Manager) configuration(name string) (*config.DatabaseDriver, error) {
 
 	driverConfig, success := m.config.Connections[name]
 
 	if ! success {
-		panic("Error: not found driver for " + name)
+		return nil, errors.New("Error: not found driver for " + name)
 	}
 
-	return &driverConfig
+	return &driverConfig, nil
 }
\ No newline at end of file
@@ -0,0 +1,205 @@
+package kernel
+
+import (
+	"database/contracts"
+	"database/kernel/config"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+)
+
+type fakeConnection struct {
+	contracts.Connection
+	pdo         *sql.DB
+	setPdosErr  error
+	reconnector func() error
+}
+
+func (c *fakeConnection) GetPDO() *sql.DB {
+
+	return c.pdo
+}
+
+func (c *fakeConnection) GetReadPDOs() []*sql.DB {
+
+	return nil
+}
+
+func (c *fakeConnection) SetPDOs(pdo *sql.DB, readPdos []*sql.DB) error {
+
+	c.pdo = pdo
+
+	return c.setPdosErr
+}
+
+func (c *fakeConnection) SetReconnector(reconnector func() error) {
+
+	c.reconnector = reconnector
+}
+
+func (c *fakeConnection) Disconnect() error {
+
+	return nil
+}
+
+type fakeFactory struct {
+	mu      sync.Mutex
+	made    int
+	release chan struct{}
+	make    func() *fakeConnection
+}
+
+func (f *fakeFactory) Make(driver *config.DatabaseDriver) (contracts.Connection, error) {
+
+	if f.release != nil {
+		<-f.release
+	}
+
+	f.mu.Lock()
+	f.made++
+	f.mu.Unlock()
+
+	if f.make != nil {
+		return f.make(), nil
+	}
+
+	return &fakeConnection{pdo: new(sql.DB)}, nil
+}
+
+func (f *fakeFactory) count() int {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.made
+}
+
+func newFakeManager(factory *fakeFactory) *Manager {
+
+	return &Manager{
+		config: &config.DatabaseConfig{
+			Default:     "default",
+			Connections: map[string]config.DatabaseDriver{"default": {Driver: "fake"}},
+		},
+		factory:     factory,
+		connections: make(map[string]contracts.Connection),
+		pending:     make(map[string]*pendingConnection),
+	}
+}
+
+func TestReconnectPropagatesSetPdosErrors(t *testing.T) {
+
+	closeErr := errors.New("close failed")
+	manager := newFakeManager(&fakeFactory{make: func() *fakeConnection {
+		return &fakeConnection{pdo: new(sql.DB), setPdosErr: closeErr}
+	}})
+
+	if _, err := manager.Connection(""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := manager.Reconnect(""); !errors.Is(err, closeErr) {
+		t.Fatalf("expected %v, got %v", closeErr, err)
+	}
+}
+
+func TestReconnectorPropagatesSetPdosErrors(t *testing.T) {
+
+	closeErr := errors.New("close failed")
+	manager := newFakeManager(&fakeFactory{make: func() *fakeConnection {
+		return &fakeConnection{pdo: new(sql.DB), setPdosErr: closeErr}
+	}})
+
+	connection, err := manager.Connection("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reconnector := connection.(*fakeConnection).reconnector
+	if reconnector == nil {
+		t.Fatal("expected a reconnector to be installed")
+	}
+
+	if err := reconnector(); !errors.Is(err, closeErr) {
+		t.Fatalf("expected %v, got %v", closeErr, err)
+	}
+}
+
+func TestReconnectSwapsPdos(t *testing.T) {
+
+	manager := newFakeManager(&fakeFactory{})
+
+	connection, err := manager.Connection("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stale := connection.GetPDO()
+
+	reconnected, err := manager.Reconnect("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if reconnected != connection {
+		t.Fatal("expected reconnect to keep the resolved connection")
+	}
+
+	if connection.GetPDO() == stale {
+		t.Fatal("expected reconnect to install a fresh pdo")
+	}
+}
+
+func TestConcurrentConnectionsShareOneFactoryCall(t *testing.T) {
+
+	factory := &fakeFactory{release: make(chan struct{})}
+	manager := newFakeManager(factory)
+
+	const callers = 16
+
+	var started, finished sync.WaitGroup
+	connections := make([]contracts.Connection, callers)
+	errs := make([]error, callers)
+
+	started.Add(callers)
+	finished.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func(i int) {
+			defer finished.Done()
+			started.Done()
+			connections[i], errs[i] = manager.Connection("")
+		}(i)
+	}
+
+	started.Wait()
+	close(factory.release)
+	finished.Wait()
+
+	if made := factory.count(); made != 1 {
+		t.Fatalf("expected one factory call, got %d", made)
+	}
+
+	for i := 0; i < callers; i++ {
+		if errs[i] != nil {
+			t.Fatalf("unexpected error: %v", errs[i])
+		}
+
+		if connections[i] != connections[0] {
+			t.Fatalf("expected caller %d to share the resolved connection", i)
+		}
+	}
+}
+
+func TestFailedConnectionIsNotCached(t *testing.T) {
+
+	manager := newFakeManager(&fakeFactory{})
+
+	if _, err := manager.Connection("missing"); err == nil {
+		t.Fatal("expected an error for an unknown connection")
+	}
+
+	if len(manager.pending) != 0 || len(manager.connections) != 0 {
+		t.Fatal("expected failed connections to leave no state behind")
+	}
+}
@@ -16,6 +16,7 @@ func NewDatabaseManager(c *config.DatabaseConfig) contracts.Manager {
 		config: c,
 		factory: newConnectionFactory(),
 		connections: make(map[string]contracts.Connection),
+		pending: make(map[string]*pendingConnection),
 	}
 }
 
@@ -0,0 +1,36 @@
+package migrations
+
+import "database/contracts"
+
+type MigrationCallback = func(connection contracts.Connection) error
+
+type Migration struct {
+	up   MigrationCallback
+	down MigrationCallback
+}
+
+func NewMigration(up MigrationCallback, down MigrationCallback) contracts.Migration {
+
+	return &Migration{
+		up:   up,
+		down: down,
+	}
+}
+
+func (m *Migration) Up(connection contracts.Connection) error {
+
+	if m.up == nil {
+		return nil
+	}
+
+	return m.up(connection)
+}
+
+func (m *Migration) Down(connection contracts.Connection) error {
+
+	if m.down == nil {
+		return nil
+	}
+
+	return m.down(connection)
+}
@@ -0,0 +1,237 @@
+package migrations
+
+import (
+	"context"
+	"database/contracts"
+	"errors"
+	"sort"
+)
+
+type Migrator struct {
+	connection contracts.Connection
+	repository *Repository
+	migrations map[string]contracts.Migration
+}
+
+type MigrationStatus struct {
+	Name  string
+	Ran   bool
+	Batch int
+}
+
+func NewMigrator(connection contracts.Connection, table string) *Migrator {
+
+	return &Migrator{
+		connection: connection,
+		repository: NewRepository(connection, table),
+		migrations: make(map[string]contracts.Migration),
+	}
+}
+
+func (m *Migrator) Register(name string, migration contracts.Migration) *Migrator {
+
+	m.migrations[name] = migration
+
+	return m
+}
+
+func (m *Migrator) GetRepository() *Repository {
+
+	return m.repository
+}
+
+func (m *Migrator) Migrate() ([]string, error) {
+
+	if err := m.prepareRepository(); err != nil {
+		return nil, err
+	}
+
+	ran, err := m.repository.GetRan()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := m.pendingMigrations(ran)
+	if len(pending) <= 0 {
+		return pending, nil
+	}
+
+	batch, err := m.repository.GetNextBatchNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0, len(pending))
+	for _, name := range pending {
+		if err := m.runMigration(name, true); err != nil {
+			return res, err
+		}
+
+		if err := m.repository.Log(name, batch); err != nil {
+			return res, err
+		}
+
+		res = append(res, name)
+	}
+
+	return res, nil
+}
+
+func (m *Migrator) Rollback(steps int) ([]string, error) {
+
+	if err := m.prepareRepository(); err != nil {
+		return nil, err
+	}
+
+	var (
+		records []MigrationRecord
+		err     error
+	)
+
+	if steps > 0 {
+		records, err = m.repository.GetMigrations(steps)
+	} else {
+		records, err = m.repository.GetLast()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(records))
+	for _, record := range records {
+		names = append(names, record.Migration)
+	}
+
+	return m.rollbackMigrations(names)
+}
+
+func (m *Migrator) Reset() ([]string, error) {
+
+	if err := m.prepareRepository(); err != nil {
+		return nil, err
+	}
+
+	ran, err := m.repository.GetRan()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(ran))
+	for i := len(ran) - 1; i >= 0; i-- {
+		names = append(names, ran[i])
+	}
+
+	return m.rollbackMigrations(names)
+}
+
+func (m *Migrator) Refresh() ([]string, error) {
+
+	if _, err := m.Reset(); err != nil {
+		return nil, err
+	}
+
+	return m.Migrate()
+}
+
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+
+	if err := m.prepareRepository(); err != nil {
+		return nil, err
+	}
+
+	batches, err := m.repository.GetMigrationBatches()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]MigrationStatus, 0, len(m.migrations))
+	for _, name := range m.migrationNames() {
+		batch, ran := batches[name]
+		res = append(res, MigrationStatus{Name: name, Ran: ran, Batch: batch})
+	}
+
+	return res, nil
+}
+
+func (m *Migrator) rollbackMigrations(names []string) ([]string, error) {
+
+	res := make([]string, 0, len(names))
+	for _, name := range names {
+		if err := m.runMigration(name, false); err != nil {
+			return res, err
+		}
+
+		if err := m.repository.Delete(name); err != nil {
+			return res, err
+		}
+
+		res = append(res, name)
+	}
+
+	return res, nil
+}
+
+func (m *Migrator) runMigration(name string, up bool) error {
+
+	migration, ok := m.migrations[name]
+	if !ok {
+		return errors.New("Migration not found: " + name)
+	}
+
+	run := func(connection contracts.Connection) error {
+		if up {
+			return migration.Up(connection)
+		}
+
+		return migration.Down(connection)
+	}
+
+	grammar := m.connection.GetSchemaGrammar()
+	if grammar == nil || !grammar.SupportsSchemaTransactions() {
+		return run(m.connection)
+	}
+
+	return m.connection.Transaction(context.Background(), func(tc contracts.TransactionConnection) error {
+		return run(tc)
+	}, 1)
+}
+
+func (m *Migrator) prepareRepository() error {
+
+	exists, err := m.repository.RepositoryExists()
+	if err != nil || exists {
+		return err
+	}
+
+	return m.repository.CreateRepository()
+}
+
+func (m *Migrator) pendingMigrations(ran []string) []string {
+
+	ranMap := make(map[string]bool, len(ran))
+	for _, name := range ran {
+		ranMap[name] = true
+	}
+
+	res := make([]string, 0)
+	for _, name := range m.migrationNames() {
+		if !ranMap[name] {
+			res = append(res, name)
+		}
+	}
+
+	return res
+}
+
+func (m *Migrator) migrationNames() []string {
+
+	names := make([]string, 0, len(m.migrations))
+	for name := range m.migrations {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,133 @@
+package migrations
+
+import (
+	"database/contracts"
+	"database/sql"
+)
+
+type Repository struct {
+	connection contracts.Connection
+	table      string
+}
+
+type MigrationRecord struct {
+	Migration string `db:"migration"`
+	Batch     int    `db:"batch"`
+}
+
+func NewRepository(connection contracts.Connection, table string) *Repository {
+
+	return &Repository{
+		connection: connection,
+		table:      table,
+	}
+}
+
+func (r *Repository) GetRan() ([]string, error) {
+
+	var ran []string
+	err := r.query().OrderBy("batch", "asc").OrderBy("migration", "asc").Pluck("migration", &ran)
+
+	return ran, err
+}
+
+func (r *Repository) GetMigrations(steps int) ([]MigrationRecord, error) {
+
+	var records []MigrationRecord
+	err := r.query().
+		Where("batch", ">=", 1).
+		OrderBy("batch", "desc").
+		OrderBy("migration", "desc").
+		Limit(steps).
+		Scan(&records)
+
+	return records, err
+}
+
+func (r *Repository) GetLast() ([]MigrationRecord, error) {
+
+	batch, err := r.GetLastBatchNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	var records []MigrationRecord
+	err = r.query().Where("batch", batch).OrderBy("migration", "desc").Scan(&records)
+
+	return records, err
+}
+
+func (r *Repository) GetMigrationBatches() (map[string]int, error) {
+
+	var records []MigrationRecord
+	if err := r.query().OrderBy("batch", "asc").OrderBy("migration", "asc").Scan(&records); err != nil {
+		return nil, err
+	}
+
+	batches := make(map[string]int, len(records))
+	for _, record := range records {
+		batches[record.Migration] = record.Batch
+	}
+
+	return batches, nil
+}
+
+func (r *Repository) Log(name string, batch int) error {
+
+	_, err := r.query().Insert(map[string]interface{}{
+		"migration": name,
+		"batch":     batch,
+	})
+
+	return err
+}
+
+func (r *Repository) Delete(name string) error {
+
+	_, err := r.query().Where("migration", name).Delete()
+
+	return err
+}
+
+func (r *Repository) GetNextBatchNumber() (int, error) {
+
+	batch, err := r.GetLastBatchNumber()
+
+	return batch + 1, err
+}
+
+func (r *Repository) GetLastBatchNumber() (int, error) {
+
+	var batch int
+	err := r.query().Select("batch").OrderByDesc("batch").First(&batch)
+
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return batch, err
+}
+
+func (r *Repository) CreateRepository() error {
+
+	return r.connection.Schema().Create(r.table, func(table contracts.Blueprint) {
+		table.Increments("id")
+		table.String("migration")
+		table.Integer("batch")
+	})
+}
+
+func (r *Repository) RepositoryExists() (bool, error) {
+
+	return r.connection.Schema().HasTable(r.table)
+}
+
+func (r *Repository) DeleteRepository() error {
+
+	return r.connection.Schema().DropIfExists(r.table)
+}
+
+func (r *Repository) query() contracts.QueryBuilder {
+
+	return r.connection.Table(r.table).UseWritePdo()
+}
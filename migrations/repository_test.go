@@ -0,0 +1,51 @@
+package migrations_test
+
+import (
+	"database/connections"
+	"database/internal/fakedb"
+	"database/kernel/config"
+	"database/migrations"
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestRepositoryReadsFromTheWriteConnection(t *testing.T) {
+
+	write, read := fakedb.New(), fakedb.New()
+
+	connection := connections.NewMysqlConnection(write.Open(), &config.DatabaseDriver{})
+	connection.SetReadPDOs([]*sql.DB{read.Open()})
+
+	repository := migrations.NewRepository(connection, "migrations")
+
+	write.QueueRows([]string{"migration"}, []driver.Value{"create_users"}, []driver.Value{"create_posts"})
+	write.QueueRows([]string{"batch"}, []driver.Value{int64(2)})
+
+	ran, err := repository.GetRan()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(ran, []string{"create_users", "create_posts"}) {
+		t.Errorf("unexpected migrations %v", ran)
+	}
+
+	next, err := repository.GetNextBatchNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if next != 3 {
+		t.Errorf("expected batch 3, got %d", next)
+	}
+
+	expected := []string{
+		"select `migration` from `migrations` order by `batch` asc, `migration` asc",
+		"select `batch` from `migrations` order by `batch` desc limit 1",
+	}
+	if len(read.Queries()) > 0 || !reflect.DeepEqual(write.Queries(), expected) {
+		t.Errorf("unexpected routing\nread: %q\nwrite: %q", read.Queries(), write.Queries())
+	}
+}
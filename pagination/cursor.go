@@ -0,0 +1,72 @@
+package pagination
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+type Cursor struct {
+	Parameters        map[string]interface{} `json:"parameters"`
+	PointsToNextItems bool                   `json:"pointsToNextItems"`
+}
+
+func NewCursor(parameters map[string]interface{}, pointsToNextItems bool) *Cursor {
+
+	return &Cursor{
+		Parameters:        parameters,
+		PointsToNextItems: pointsToNextItems,
+	}
+}
+
+func (c *Cursor) Encode() string {
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(encoded string) (*Cursor, error) {
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.New("Invalid pagination cursor")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var cursor Cursor
+	if err := decoder.Decode(&cursor); err != nil || cursor.Parameters == nil {
+		return nil, errors.New("Invalid pagination cursor")
+	}
+
+	for key, value := range cursor.Parameters {
+		switch v := value.(type) {
+		case json.Number:
+			cursor.Parameters[key] = normalizeNumber(v)
+		case string, bool, nil:
+		default:
+			return nil, errors.New("Invalid pagination cursor")
+		}
+	}
+
+	return &cursor, nil
+}
+
+func normalizeNumber(number json.Number) interface{} {
+
+	if v, err := number.Int64(); err == nil {
+		return v
+	}
+
+	if v, err := number.Float64(); err == nil {
+		return v
+	}
+
+	return number.String()
+}
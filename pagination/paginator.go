@@ -0,0 +1,87 @@
+package pagination
+
+type Paginator struct {
+	Items       []map[string]interface{}
+	Total       int64
+	PerPage     int
+	CurrentPage int
+	LastPage    int
+	NextPage    int
+	PrevPage    int
+}
+
+func NewPaginator(items []map[string]interface{}, total int64, perPage int, currentPage int) *Paginator {
+
+	lastPage := int((total + int64(perPage) - 1) / int64(perPage))
+	if lastPage < 1 {
+		lastPage = 1
+	}
+
+	paginator := &Paginator{
+		Items:       items,
+		Total:       total,
+		PerPage:     perPage,
+		CurrentPage: currentPage,
+		LastPage:    lastPage,
+	}
+
+	if currentPage < lastPage {
+		paginator.NextPage = currentPage + 1
+	}
+
+	if currentPage > 1 {
+		paginator.PrevPage = currentPage - 1
+	}
+
+	return paginator
+}
+
+func (p *Paginator) HasMorePages() bool {
+
+	return p.CurrentPage < p.LastPage
+}
+
+type SimplePaginator struct {
+	Items       []map[string]interface{}
+	PerPage     int
+	CurrentPage int
+	NextPage    int
+	PrevPage    int
+}
+
+func NewSimplePaginator(items []map[string]interface{}, perPage int, currentPage int) *SimplePaginator {
+
+	paginator := &SimplePaginator{
+		Items:       items,
+		PerPage:     perPage,
+		CurrentPage: currentPage,
+	}
+
+	if len(items) > perPage {
+		paginator.Items = items[:perPage]
+		paginator.NextPage = currentPage + 1
+	}
+
+	if currentPage > 1 {
+		paginator.PrevPage = currentPage - 1
+	}
+
+	return paginator
+}
+
+func (p *SimplePaginator) HasMorePages() bool {
+
+	return p.NextPage > 0
+}
+
+type CursorPaginator struct {
+	Items      []map[string]interface{}
+	PerPage    int
+	NextCursor string
+	PrevCursor string
+}
+
+func (p *CursorPaginator) HasMorePages() bool {
+
+	return len(p.NextCursor) > 0
+}
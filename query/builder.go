@@ -1,9 +1,17 @@
 package query
 
 import (
+	"context"
 	"database/contracts"
+	"database/pagination"
 	"database/query/types"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -19,6 +27,8 @@ type Builder struct {
 	Havings   []types.WhereType
 	Columns   []types.SelectType
 
+	IsDistinct bool
+
 	RowLimit  int
 	RowOffset int
 
@@ -28,8 +38,15 @@ type Builder struct {
 
 	Unions []types.UnionType
 
+	RowLock types.LockType
+
 	bindings map[string][]interface{}
 
+	useWritePdo bool
+
+	err error
+
+	ctx        context.Context
 	grammar    contracts.Grammar
 	connection contracts.Connection
 }
@@ -37,6 +54,7 @@ type Builder struct {
 func NewBuilder(connection contracts.Connection, grammar contracts.Grammar) contracts.QueryBuilder {
 
 	return &Builder{
+		ctx:        context.Background(),
 		grammar:    grammar,
 		connection: connection,
 		bindings: map[string][]interface{}{
@@ -51,6 +69,20 @@ func NewBuilder(connection contracts.Connection, grammar contracts.Grammar) cont
 	}
 }
 
+func (b *Builder) WithContext(ctx context.Context) contracts.QueryBuilder {
+
+	b.ctx = ctx
+
+	return b
+}
+
+func (b *Builder) UseWritePdo() contracts.QueryBuilder {
+
+	b.useWritePdo = true
+
+	return b
+}
+
 func (b *Builder) Select(args ...string) contracts.QueryBuilder {
 
 	for _, arg := range args {
@@ -74,6 +106,13 @@ func (b *Builder) SelectRaw(args ...interface{}) contracts.QueryBuilder {
 	return b
 }
 
+func (b *Builder) Distinct() contracts.QueryBuilder {
+
+	b.IsDistinct = true
+
+	return b
+}
+
 func (b *Builder) SelectSub(query interface{}, as string) contracts.QueryBuilder {
 
 	subQuery, bindings := b.createSub(query)
@@ -107,10 +146,11 @@ func (b *Builder) parseSub(query interface{}) (string, []interface{}) {
 	case string:
 		return v, []interface{}{}
 	case *Builder:
+		b.inheritError(v)
 		return v.ToSql(), v.GetBindingsForSql()
 	default:
-		panic("Illegal sub query")
-
+		b.setError(errors.New("Illegal sub query"))
+		return "", []interface{}{}
 	}
 }
 
@@ -243,16 +283,11 @@ func (b *Builder) buildWhereDate(dateType string, format string, logic string, a
 
 	col, value, operator := b.prepareArguments(args...)
 
-	switch v := value.(type) {
-	case string:
-		value = v
-		break
-	case time.Time:
+	if v, ok := value.(time.Time); ok {
 		value = v.Format(format)
-		break
 	}
 
-	whereType := types.NewWhereDate(col, operator, value.(string), dateType, logic)
+	whereType := types.NewWhereDate(col, operator, fmt.Sprint(value), dateType, logic)
 	b.Wheres = append(b.Wheres, whereType)
 
 	b.addBinding(value, "where")
@@ -320,6 +355,8 @@ func (b *Builder) buildJoin(table interface{}, args []interface{}, joinType stri
 		b.Joins = append(b.Joins, join)
 	}
 
+	b.inheritError(join.(*JoinClause).Builder)
+
 	for _, v := range join.GetBindingsForSql() {
 		b.addBinding(v, "join")
 	}
@@ -351,7 +388,18 @@ func (b *Builder) buildUnion(query interface{}, all bool) contracts.QueryBuilder
 		query = newQuery.(*Builder)
 	}
 
-	b.Unions = append(b.Unions, types.NewUnion(query.(*Builder), all))
+	union, ok := query.(*Builder)
+	if !ok {
+		b.setError(errors.New("Illegal union query"))
+		return b
+	}
+
+	b.inheritError(union)
+	b.Unions = append(b.Unions, types.NewUnion(union, all))
+
+	for _, v := range union.GetBindingsForSql() {
+		b.addBinding(v, "union")
+	}
 
 	return b
 }
@@ -558,6 +606,8 @@ func (b *Builder) whereNested(callback func(q contracts.QueryBuilder), logic str
 	callback(newQuery)
 	query := newQuery.(*Builder)
 
+	b.inheritError(query)
+
 	if len(query.Wheres) > 0 {
 		b.Wheres = append(b.Wheres, types.NewWhereNested(query, logic))
 		for _, v := range query.getRawBindings()["where"] {
@@ -579,6 +629,8 @@ func (b *Builder) whereSub(
 	callback(newQuery)
 	query := newQuery.(*Builder)
 
+	b.inheritError(query)
+
 	b.Wheres = append(b.Wheres, types.NewWhereSub(column, operator, query, logic))
 
 	for _, v := range query.GetBindingsForSql() {
@@ -658,6 +710,54 @@ func (b *Builder) Offset(n int) contracts.QueryBuilder {
 	return b
 }
 
+func (b *Builder) LockForUpdate() contracts.QueryBuilder {
+
+	b.RowLock = types.NewLock("update", "")
+	b.useWritePdo = true
+
+	return b
+}
+
+func (b *Builder) SharedLock() contracts.QueryBuilder {
+
+	b.RowLock = types.NewLock("share", "")
+	b.useWritePdo = true
+
+	return b
+}
+
+func (b *Builder) Lock(raw string) contracts.QueryBuilder {
+
+	b.RowLock = types.NewLockRaw(raw)
+	b.useWritePdo = true
+
+	return b
+}
+
+func (b *Builder) SkipLocked() contracts.QueryBuilder {
+
+	return b.setLockModifier("skip locked")
+}
+
+func (b *Builder) NoWait() contracts.QueryBuilder {
+
+	return b.setLockModifier("nowait")
+}
+
+func (b *Builder) setLockModifier(modifier string) contracts.QueryBuilder {
+
+	if !b.grammar.SupportsLockModifiers() {
+		b.setError(errors.New("This database engine does not support the " + modifier + " lock modifier"))
+		return b
+	}
+
+	if b.RowLock != nil && b.RowLock.GetMode() != "raw" {
+		b.RowLock = types.NewLock(b.RowLock.GetMode(), modifier)
+	}
+
+	return b
+}
+
 func (b *Builder) Union(query interface{}) contracts.QueryBuilder {
 
 	return b.buildUnion(query, false)
@@ -673,38 +773,458 @@ func (b *Builder) Get() (*sql.Rows, error) {
 	return b.runSelect()
 }
 
-func (b *Builder) Count(column string) (*sql.Rows, error) {
+func (b *Builder) Scan(dest interface{}) error {
+
+	rows, err := b.Get()
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
 
-	return b.aggregate("count", column)
+	return scanRows(rows, dest, false)
 }
 
-func (b *Builder) Min(column string) (*sql.Rows, error) {
+func (b *Builder) First(dest interface{}) error {
+
+	clone := b.clone()
+	clone.Limit(1)
+
+	rows, err := clone.Get()
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
 
-	return b.aggregate("min", column)
+	return scanRows(rows, dest, true)
 }
 
-func (b *Builder) Max(column string) (*sql.Rows, error) {
+func (b *Builder) Pluck(column string, dest interface{}) error {
 
-	return b.aggregate("max", column)
+	clone := b.clone()
+	clone.Columns = []types.SelectType{types.NewSelectString(column)}
+	clone.bindings["select"] = make([]interface{}, 0)
+
+	return clone.Scan(dest)
 }
 
-func (b *Builder) Sum(column string) (*sql.Rows, error) {
+func (b *Builder) Value(column string) (interface{}, error) {
+
+	clone := b.clone()
+	clone.Columns = []types.SelectType{types.NewSelectString(column)}
+	clone.bindings["select"] = make([]interface{}, 0)
+
+	var value interface{}
+	if err := clone.First(&value); err != nil {
+		return nil, err
+	}
 
-	return b.aggregate("sum", column)
+	return normalizeValue(value), nil
 }
 
-func (b *Builder) Avg(column string) (*sql.Rows, error) {
+func (b *Builder) Chunk(size int, callback func(rows []map[string]interface{}) error) error {
 
-	return b.aggregate("avg", column)
+	if size < 1 {
+		return errors.New("The chunk size should be at least 1")
+	}
+
+	if len(b.Orders) <= 0 && len(b.UnionOrders) <= 0 {
+		return errors.New("You must specify an orderBy clause when using chunk")
+	}
+
+	for page := 0; ; page++ {
+		clone := b.clone()
+		clone.Offset(page * size).Limit(size)
+
+		var results []map[string]interface{}
+		if err := clone.Scan(&results); err != nil {
+			return err
+		}
+
+		if len(results) <= 0 {
+			return nil
+		}
+
+		if err := callback(results); err != nil {
+			return err
+		}
+
+		if len(results) < size {
+			return nil
+		}
+	}
 }
 
-func (b *Builder) aggregate(function string, column string) (*sql.Rows, error) {
+func (b *Builder) ChunkById(size int, column string, callback func(rows []map[string]interface{}) error) error {
 
-	var clone = *b
-	clone.Columns = []types.SelectType{types.NewSelectString(column)}
-	clone.setAggregate(function, column)
+	if size < 1 {
+		return errors.New("The chunk size should be at least 1")
+	}
+
+	alias := b.columnAlias(column)
+
+	var lastId interface{}
+
+	for {
+		clone := b.forPageAfterId(size, column, lastId)
+
+		var results []map[string]interface{}
+		if err := clone.Scan(&results); err != nil {
+			return err
+		}
+
+		if len(results) <= 0 {
+			return nil
+		}
+
+		if err := callback(results); err != nil {
+			return err
+		}
+
+		if len(results) < size {
+			return nil
+		}
+
+		lastId = results[len(results)-1][alias]
+		if lastId == nil {
+			return errors.New("The chunkById operation was aborted because the [" + alias + "] column is not present in the query result")
+		}
+	}
+}
+
+func (b *Builder) forPageAfterId(size int, column string, lastId interface{}) *Builder {
+
+	clone := b.clone()
+	clone.Orders = nil
+	clone.bindings["order"] = make([]interface{}, 0)
+
+	if lastId != nil {
+		clone.groupOrWheres()
+		clone.Where(column, ">", lastId)
+	}
+
+	clone.OrderBy(column, "asc").Limit(size)
+
+	return clone
+}
+
+func (b *Builder) groupOrWheres() {
+
+	for _, where := range b.Wheres {
+		if strings.ToLower(where.GetLogic()) != "or" {
+			continue
+		}
+
+		nested := b.forNestedWhere().(*Builder)
+		nested.Wheres = b.Wheres
+		nested.bindings["where"] = b.bindings["where"]
+
+		b.Wheres = []types.WhereType{types.NewWhereNested(nested, "and")}
+
+		return
+	}
+}
+
+func (b *Builder) Cursor() (contracts.Cursor, error) {
+
+	rows, err := b.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return newCursor(rows)
+}
+
+func (b *Builder) Paginate(perPage int, page int) (*pagination.Paginator, error) {
+
+	perPage, page = b.normalizePage(perPage, page)
+
+	total, err := b.getCountForPagination()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]map[string]interface{}, 0)
+	if total > 0 {
+		clone := b.clone()
+		clone.Offset((page - 1) * perPage).Limit(perPage)
+
+		if err := clone.Scan(&items); err != nil {
+			return nil, err
+		}
+	}
+
+	return pagination.NewPaginator(items, total, perPage, page), nil
+}
+
+func (b *Builder) SimplePaginate(perPage int, page int) (*pagination.SimplePaginator, error) {
+
+	perPage, page = b.normalizePage(perPage, page)
+
+	clone := b.clone()
+	clone.Offset((page - 1) * perPage).Limit(perPage + 1)
+
+	var items []map[string]interface{}
+	if err := clone.Scan(&items); err != nil {
+		return nil, err
+	}
+
+	return pagination.NewSimplePaginator(items, perPage, page), nil
+}
+
+func (b *Builder) CursorPaginate(perPage int, cursor string) (*pagination.CursorPaginator, error) {
+
+	perPage, _ = b.normalizePage(perPage, 1)
+
+	if len(b.Orders) <= 0 {
+		return nil, errors.New("You must specify an orderBy clause when using cursor pagination")
+	}
+
+	for _, order := range b.Orders {
+		if _, ok := order.(types.ExpressionType); ok {
+			return nil, errors.New("Cursor pagination does not support raw order clauses")
+		}
+	}
+
+	var current *pagination.Cursor
+	if len(cursor) > 0 {
+		var err error
+		if current, err = pagination.DecodeCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	clone := b.clone()
+
+	if current != nil {
+		clone.groupOrWheres()
+
+		if err := clone.whereCursor(b.Orders, current); err != nil {
+			return nil, err
+		}
+
+		if !current.PointsToNextItems {
+			clone.Orders = make([]types.OrderType, 0, len(b.Orders))
+			for _, order := range b.Orders {
+				clone.Orders = append(clone.Orders, types.NewOrder(order.GetColumn(), b.oppositeDirection(order)))
+			}
+		}
+	}
+
+	clone.Limit(perPage + 1)
+
+	var items []map[string]interface{}
+	if err := clone.Scan(&items); err != nil {
+		return nil, err
+	}
+
+	hasMore := len(items) > perPage
+	if hasMore {
+		items = items[:perPage]
+	}
+
+	if current != nil && !current.PointsToNextItems {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	paginator := &pagination.CursorPaginator{Items: items, PerPage: perPage}
+
+	if len(items) <= 0 {
+		return paginator, nil
+	}
+
+	if (current == nil && hasMore) || (current != nil && (!current.PointsToNextItems || hasMore)) {
+		paginator.NextCursor = b.cursorForItem(items[len(items)-1], true).Encode()
+	}
+
+	if current != nil && (current.PointsToNextItems || hasMore) {
+		paginator.PrevCursor = b.cursorForItem(items[0], false).Encode()
+	}
+
+	return paginator, nil
+}
+
+func (b *Builder) whereCursor(orders []types.OrderType, cursor *pagination.Cursor) error {
+
+	values := make([]interface{}, 0, len(orders))
+	for _, order := range orders {
+		value, ok := cursor.Parameters[b.columnAlias(order.GetColumn())]
+		if !ok || value == nil {
+			return errors.New("Pagination cursor is missing the [" + order.GetColumn() + "] column")
+		}
+
+		values = append(values, value)
+	}
+
+	b.Where(func(q contracts.QueryBuilder) {
+		for i := range orders {
+			q.OrWhere(func(q contracts.QueryBuilder) {
+				for j := 0; j < i; j++ {
+					q.Where(orders[j].GetColumn(), "=", values[j])
+				}
+
+				operator := ">"
+				if (orders[i].GetDirection() == "asc") != cursor.PointsToNextItems {
+					operator = "<"
+				}
+
+				q.Where(orders[i].GetColumn(), operator, values[i])
+			})
+		}
+	})
+
+	return nil
+}
+
+func (b *Builder) cursorForItem(item map[string]interface{}, pointsToNextItems bool) *pagination.Cursor {
+
+	parameters := make(map[string]interface{}, len(b.Orders))
+	for _, order := range b.Orders {
+		alias := b.columnAlias(order.GetColumn())
+		parameters[alias] = item[alias]
+	}
 
-	return clone.Get()
+	return pagination.NewCursor(parameters, pointsToNextItems)
+}
+
+func (b *Builder) oppositeDirection(order types.OrderType) string {
+
+	if order.GetDirection() == "asc" {
+		return "desc"
+	}
+
+	return "asc"
+}
+
+func (b *Builder) columnAlias(column string) string {
+
+	if i := strings.LastIndex(column, "."); i >= 0 {
+		return column[i+1:]
+	}
+
+	return column
+}
+
+func (b *Builder) normalizePage(perPage int, page int) (int, int) {
+
+	if perPage < 1 {
+		perPage = 15
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	return perPage, page
+}
+
+func (b *Builder) getCountForPagination() (int64, error) {
+
+	clone := b.clone()
+	clone.Orders = nil
+	clone.UnionOrders = nil
+	clone.bindings["order"] = make([]interface{}, 0)
+	clone.RowLimit, clone.RowOffset = 0, 0
+	clone.UnionLimit, clone.UnionOffset = 0, 0
+
+	if len(clone.Groups) <= 0 && len(clone.Havings) <= 0 && len(clone.Unions) <= 0 && !clone.IsDistinct {
+		clone.Columns = []types.SelectType{}
+		clone.bindings["select"] = make([]interface{}, 0)
+	}
+
+	return clone.Count()
+}
+
+func (b *Builder) Count(columns ...string) (int64, error) {
+
+	if len(columns) <= 0 {
+		columns = []string{"*"}
+	}
+
+	var res int64
+	err := b.aggregate("count", columns, &res)
+
+	return res, err
+}
+
+func (b *Builder) Min(column string) (interface{}, error) {
+
+	var res interface{}
+	err := b.aggregate("min", []string{column}, &res)
+
+	return normalizeValue(res), err
+}
+
+func (b *Builder) Max(column string) (interface{}, error) {
+
+	var res interface{}
+	err := b.aggregate("max", []string{column}, &res)
+
+	return normalizeValue(res), err
+}
+
+func (b *Builder) Sum(column string) (float64, error) {
+
+	var res sql.NullFloat64
+	err := b.aggregate("sum", []string{column}, &res)
+
+	return res.Float64, err
+}
+
+func (b *Builder) Avg(column string) (sql.NullFloat64, error) {
+
+	var res sql.NullFloat64
+	err := b.aggregate("avg", []string{column}, &res)
+
+	return res, err
+}
+
+func (b *Builder) aggregate(function string, columns []string, dest interface{}) error {
+
+	rows, err := b.aggregateQuery(function, columns).Get()
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	return scanRows(rows, dest, true)
+}
+
+func (b *Builder) aggregateQuery(function string, columns []string) *Builder {
+
+	distinctAll := b.IsDistinct && strings.Join(columns, ", ") == "*"
+
+	if len(b.Unions) > 0 || len(b.Groups) > 0 || len(b.Havings) > 0 || distinctAll {
+		return b.aggregateSubQuery(function, columns)
+	}
+
+	clone := b.clone()
+	clone.Columns = []types.SelectType{}
+	clone.bindings["select"] = make([]interface{}, 0)
+	clone.Orders = nil
+	clone.bindings["order"] = make([]interface{}, 0)
+	clone.setAggregate(function, strings.Join(columns, ", "))
+
+	return clone
+}
+
+func (b *Builder) aggregateSubQuery(function string, columns []string) *Builder {
+
+	outerColumns := make([]string, 0, len(columns))
+	for _, col := range columns {
+		segments := strings.Split(col, ".")
+		outerColumns = append(outerColumns, segments[len(segments)-1])
+	}
+
+	query := b.newQuery().FromSub(b.clone(), "temp_table").(*Builder)
+	query.useWritePdo = b.useWritePdo
+	query.setAggregate(function, strings.Join(outerColumns, ", "))
+
+	return query
 }
 
 func (b *Builder) setAggregate(function string, column string) contracts.QueryBuilder {
@@ -714,13 +1234,160 @@ func (b *Builder) setAggregate(function string, column string) contracts.QueryBu
 	return b
 }
 
-func (b *Builder) Insert(values ...map[string]interface{}) sql.Result {
+func (b *Builder) Insert(values ...map[string]interface{}) (sql.Result, error) {
+
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	if len(values) <= 0 {
+		return driver.RowsAffected(0), nil
+	}
+
+	columns, bindings := b.prepareInsertValues(values)
+
+	return b.connection.InsertContext(b.ctx, b.grammar.CompileInsert(b, values, columns), bindings)
+}
+
+func (b *Builder) InsertGetId(values map[string]interface{}, sequence string) (int64, error) {
+
+	ids, err := b.InsertGetIds([]map[string]interface{}{values}, sequence)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(ids) <= 0 {
+		return 0, sql.ErrNoRows
+	}
+
+	return ids[0], nil
+}
+
+func (b *Builder) InsertGetIds(values []map[string]interface{}, sequence string) ([]int64, error) {
+
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	if len(values) <= 0 {
+		return []int64{}, nil
+	}
+
+	if len(sequence) <= 0 {
+		sequence = "id"
+	}
+
+	if !b.grammar.SupportsReturning() && len(values) > 1 {
+		return nil, errors.New("This database engine cannot return the ids of a multi-row insert")
+	}
+
+	columns, bindings := b.prepareInsertValues(values)
+	query := b.grammar.CompileInsertGetId(b, values, columns, sequence)
+
+	if b.grammar.SupportsReturning() {
+		return b.insertReturning(query, bindings)
+	}
+
+	res, err := b.connection.InsertContext(b.ctx, query, bindings)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return []int64{id}, nil
+}
+
+func (b *Builder) insertReturning(query string, bindings []interface{}) ([]int64, error) {
+
+	rows, err := b.connection.SelectFromWriteConnectionContext(b.ctx, query, bindings)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	b.connection.MarkRecordsModified(b.ctx)
+
+	var ids []int64
+	if err := scanRows(rows, &ids, false); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (b *Builder) InsertOrIgnore(values ...map[string]interface{}) (int64, error) {
+
+	if b.err != nil {
+		return 0, b.err
+	}
+
+	if !b.grammar.SupportsInsertOrIgnore() {
+		return 0, errors.New("This database engine does not support inserting while ignoring errors")
+	}
+
+	if len(values) <= 0 {
+		return 0, nil
+	}
+
+	columns, bindings := b.prepareInsertValues(values)
+
+	return b.affectingInsert(b.grammar.CompileInsertOrIgnore(b, values, columns), bindings)
+}
+
+func (b *Builder) Upsert(values []map[string]interface{}, uniqueBy []string, update []string) (int64, error) {
+
+	if b.err != nil {
+		return 0, b.err
+	}
+
+	if len(values) <= 0 {
+		return 0, nil
+	}
+
+	columns, bindings := b.prepareInsertValues(values)
+
+	if len(update) <= 0 {
+		update = columns
+	}
+
+	return b.affectingInsert(b.grammar.CompileUpsert(b, values, columns, uniqueBy, update), bindings)
+}
+
+func (b *Builder) InsertUsing(columns []string, query interface{}) (int64, error) {
+
+	subQuery, bindings := b.createSub(query)
+
+	return b.affectingInsert(b.grammar.CompileInsertUsing(b, columns, subQuery), bindings)
+}
+
+func (b *Builder) affectingInsert(query string, bindings []interface{}) (int64, error) {
+
+	if b.err != nil {
+		return 0, b.err
+	}
+
+	res, err := b.connection.InsertContext(b.ctx, query, bindings)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (b *Builder) prepareInsertValues(values []map[string]interface{}) ([]string, []interface{}) {
 
 	var columns []string
 	for col, _ := range values[0:1][0] {
 		columns = append(columns, col)
 	}
 
+	sort.Strings(columns)
+
 	var bindings []interface{}
 	for _, val := range values {
 		for _, col := range columns {
@@ -728,31 +1395,51 @@ func (b *Builder) Insert(values ...map[string]interface{}) sql.Result {
 		}
 	}
 
-	return b.connection.Insert(b.grammar.CompileInsert(b, values, columns), bindings)
+	return columns, bindings
 }
 
-func (b *Builder) Update(values map[string]interface{}) int64 {
+func (b *Builder) Update(values map[string]interface{}) (int64, error) {
+
+	if b.err != nil {
+		return 0, b.err
+	}
 
 	query := b.grammar.CompileUpdate(b, values)
 
-	return b.connection.Update(query, b.grammar.PrepareBindingsForUpdate(b, b.bindings, values))
+	return b.connection.UpdateContext(b.ctx, query, b.grammar.PrepareBindingsForUpdate(b, b.bindings, values))
 }
 
-func (b *Builder) Delete() int64 {
+func (b *Builder) Delete() (int64, error) {
+
+	if b.err != nil {
+		return 0, b.err
+	}
 
 	query := b.grammar.CompileDelete(b)
 
-	return b.connection.Delete(query, b.grammar.PrepareBindingsForDelete(b, b.bindings))
+	return b.connection.DeleteContext(b.ctx, query, b.grammar.PrepareBindingsForDelete(b, b.bindings))
 }
 
-func (b *Builder) Truncate() sql.Result {
+func (b *Builder) Truncate() (sql.Result, error) {
+
+	if b.err != nil {
+		return nil, b.err
+	}
 
-	return b.connection.Statement(b.grammar.CompileTruncate(b), []interface{}{})
+	return b.connection.StatementContext(b.ctx, b.grammar.CompileTruncate(b), []interface{}{})
 }
 
 func (b *Builder) runSelect() (*sql.Rows, error) {
 
-	return b.connection.Select(b.ToSql(), b.GetBindingsForSql())
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	if b.useWritePdo {
+		return b.connection.SelectFromWriteConnectionContext(b.ctx, b.ToSql(), b.GetBindingsForSql())
+	}
+
+	return b.connection.SelectContext(b.ctx, b.ToSql(), b.GetBindingsForSql())
 }
 
 func (b *Builder) ToSql() string {
@@ -760,6 +1447,43 @@ func (b *Builder) ToSql() string {
 	return b.grammar.CompileSelect(b)
 }
 
+func (b *Builder) ToRawSql() string {
+
+	return b.grammar.SubstituteBindingsIntoRawSql(b.ToSql(), b.GetBindingsForSql())
+}
+
+func (b *Builder) ToRawInsertSql(values ...map[string]interface{}) string {
+
+	if len(values) <= 0 {
+		return ""
+	}
+
+	columns, bindings := b.prepareInsertValues(values)
+
+	return b.grammar.SubstituteBindingsIntoRawSql(b.grammar.CompileInsert(b, values, columns), bindings)
+}
+
+func (b *Builder) ToRawUpdateSql(values map[string]interface{}) string {
+
+	query := b.grammar.CompileUpdate(b, values)
+
+	return b.grammar.SubstituteBindingsIntoRawSql(query, b.grammar.PrepareBindingsForUpdate(b, b.bindings, values))
+}
+
+func (b *Builder) ToRawDeleteSql() string {
+
+	query := b.grammar.CompileDelete(b)
+
+	return b.grammar.SubstituteBindingsIntoRawSql(query, b.grammar.PrepareBindingsForDelete(b, b.bindings))
+}
+
+func (b *Builder) Dump(w io.Writer) contracts.QueryBuilder {
+
+	fmt.Fprintln(w, b.ToRawSql())
+
+	return b
+}
+
 func (b *Builder) isCallback(arg interface{}) bool {
 
 	_, ok := arg.(types.WhereCallback)
@@ -800,10 +1524,27 @@ func (b *Builder) getWhereTypeByValue(col string, operator string, value interfa
 		return types.NewWhereFloat32(col, operator, v, logic)
 	case bool:
 		return types.NewWhereBool(col, operator, v, logic)
+	case int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float64, []byte, time.Time:
+		return types.NewWhereValue(col, operator, v, logic)
 	case nil:
 		return types.NewWhereNull(col, operator, logic)
 	default:
-		panic("Illegal where type")
+		b.setError(fmt.Errorf("Illegal where type %T for column %s", value, col))
+		return types.NewWhereValue(col, operator, v, logic)
+	}
+}
+
+func (b *Builder) setError(err error) {
+
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+func (b *Builder) inheritError(query *Builder) {
+
+	if query.err != nil {
+		b.setError(query.err)
 	}
 }
 
@@ -843,6 +1584,27 @@ func (b *Builder) GetBindingsForSql(except ...string) []interface{} {
 	return res
 }
 
+func (b *Builder) clone() *Builder {
+
+	var clone = *b
+
+	clone.Joins = append([]contracts.JoinQueryBuilder{}, b.Joins...)
+	clone.Orders = append([]types.OrderType{}, b.Orders...)
+	clone.Wheres = append([]types.WhereType{}, b.Wheres...)
+	clone.Groups = append([]string{}, b.Groups...)
+	clone.Havings = append([]types.WhereType{}, b.Havings...)
+	clone.Columns = append([]types.SelectType{}, b.Columns...)
+	clone.UnionOrders = append([]types.OrderType{}, b.UnionOrders...)
+	clone.Unions = append([]types.UnionType{}, b.Unions...)
+
+	clone.bindings = make(map[string][]interface{}, len(b.bindings))
+	for k, v := range b.bindings {
+		clone.bindings[k] = append([]interface{}{}, v...)
+	}
+
+	return &clone
+}
+
 func (b *Builder) forNestedWhere() contracts.QueryBuilder {
 
 	return b.newQuery().From(b.Table.ToString())
@@ -855,5 +1617,5 @@ func (b *Builder) forSubQuery() contracts.QueryBuilder {
 
 func (b *Builder) newQuery() contracts.QueryBuilder {
 
-	return NewBuilder(b.connection, b.grammar)
+	return NewBuilder(b.connection, b.grammar).WithContext(b.ctx)
 }
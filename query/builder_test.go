@@ -0,0 +1,313 @@
+package query_test
+
+import (
+	"database/connections"
+	"database/contracts"
+	"database/internal/fakedb"
+	"database/kernel/config"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newFakeConnection() (*fakedb.Server, contracts.Connection) {
+
+	server := fakedb.New()
+
+	return server, connections.NewSqliteConnection(server.Open(), &config.DatabaseDriver{})
+}
+
+func assertCalls(t *testing.T, server *fakedb.Server, expected ...fakedb.Call) {
+
+	t.Helper()
+
+	calls := server.Calls()
+	if len(calls) != len(expected) {
+		t.Fatalf("expected %d calls, got %d: %v", len(expected), len(calls), calls)
+	}
+
+	for i, call := range calls {
+		if call.Query != expected[i].Query {
+			t.Errorf("call %d sql mismatch\n got: %s\nwant: %s", i, call.Query, expected[i].Query)
+		}
+
+		if len(expected[i].Args) > 0 && !reflect.DeepEqual(call.Args, expected[i].Args) {
+			t.Errorf("call %d bindings mismatch\n got: %v\nwant: %v", i, call.Args, expected[i].Args)
+		}
+	}
+}
+
+func args(values ...driver.Value) []driver.Value {
+
+	return values
+}
+
+func TestAggregateDropsSelectBindings(t *testing.T) {
+
+	server, connection := newFakeConnection()
+
+	server.QueueRows([]string{"aggregate"}, []driver.Value{int64(3)})
+	server.QueueRows([]string{"aggregate"}, []driver.Value{int64(3)})
+
+	count, err := connection.Table("users").SelectRaw("age + ? as next_age", 5).Where("id", 1).Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 3 {
+		t.Errorf("expected 3, got %d", count)
+	}
+
+	sub := connection.Table("posts").SelectRaw("count(*)").Where("posts.user_id", ">", 2)
+	if _, err := connection.Table("users").SelectSub(sub, "posts").Where("id", 1).Count(); err != nil {
+		t.Fatal(err)
+	}
+
+	assertCalls(t, server,
+		fakedb.Call{Query: "select count(*) as aggregate from \"users\" where \"id\" = ?", Args: args(int64(1))},
+		fakedb.Call{Query: "select count(*) as aggregate from \"users\" where \"id\" = ?", Args: args(int64(1))},
+	)
+}
+
+func TestPluckAndValueDropSelectBindings(t *testing.T) {
+
+	server, connection := newFakeConnection()
+
+	server.QueueRows([]string{"name"}, []driver.Value{"Jane"})
+	server.QueueRows([]string{"name"}, []driver.Value{"Jane"})
+
+	var names []string
+	if err := connection.Table("users").SelectRaw("age + ? as next_age", 5).Where("id", 1).Pluck("name", &names); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := connection.Table("users").SelectRaw("age + ? as next_age", 5).Where("id", 1).Value("name"); err != nil {
+		t.Fatal(err)
+	}
+
+	assertCalls(t, server,
+		fakedb.Call{Query: "select \"name\" from \"users\" where \"id\" = ?", Args: args(int64(1))},
+		fakedb.Call{Query: "select \"name\" from \"users\" where \"id\" = ? limit 1", Args: args(int64(1))},
+	)
+}
+
+func TestDistinctCount(t *testing.T) {
+
+	server, connection := newFakeConnection()
+
+	server.QueueRows([]string{"aggregate"}, []driver.Value{int64(2)})
+	server.QueueRows([]string{"aggregate"}, []driver.Value{int64(4)})
+
+	if _, err := connection.Table("users").Distinct().Where("id", ">", 1).Count("name"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := connection.Table("users").Distinct().Select("name", "email").Count(); err != nil {
+		t.Fatal(err)
+	}
+
+	assertCalls(t, server,
+		fakedb.Call{
+			Query: "select count(distinct \"name\") as aggregate from \"users\" where \"id\" > ?",
+			Args:  args(int64(1)),
+		},
+		fakedb.Call{
+			Query: "select count(*) as aggregate from (select distinct \"name\", \"email\" from \"users\") as \"temp_table\"",
+		},
+	)
+}
+
+func TestInsertGetIdUsesLastInsertId(t *testing.T) {
+
+	server := fakedb.New()
+	connection := connections.NewMysqlConnection(server.Open(), &config.DatabaseDriver{})
+
+	server.QueueResult(1, 42)
+
+	id, err := connection.Table("users").InsertGetId(map[string]interface{}{"name": "Jane"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id != 42 {
+		t.Errorf("expected 42, got %d", id)
+	}
+
+	rows := []map[string]interface{}{{"name": "Jane"}, {"name": "John"}}
+	if _, err := connection.Table("users").InsertGetIds(rows, ""); err == nil {
+		t.Error("expected a multi-row insert without returning support to fail")
+	}
+
+	assertCalls(t, server, fakedb.Call{Query: "insert into `users`(`name`) values (?)", Args: args("Jane")})
+}
+
+func TestInsertGetIdsUsesReturning(t *testing.T) {
+
+	server, connection := newFakeConnection()
+
+	server.QueueRows([]string{"id"}, []driver.Value{int64(7)}, []driver.Value{int64(8)})
+
+	rows := []map[string]interface{}{{"name": "Jane"}, {"name": "John"}}
+	ids, err := connection.Table("users").InsertGetIds(rows, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(ids, []int64{7, 8}) {
+		t.Errorf("expected [7 8], got %v", ids)
+	}
+
+	assertCalls(t, server, fakedb.Call{
+		Query: "insert into \"users\"(\"name\") values (?), (?) returning \"id\"",
+		Args:  args("Jane", "John"),
+	})
+}
+
+func TestChunk(t *testing.T) {
+
+	server, connection := newFakeConnection()
+
+	server.QueueRows([]string{"id"}, []driver.Value{int64(1)}, []driver.Value{int64(2)})
+	server.QueueRows([]string{"id"}, []driver.Value{int64(3)})
+
+	var seen []interface{}
+	err := connection.Table("users").Where("active", 1).OrderBy("id", "asc").Chunk(2, func(rows []map[string]interface{}) error {
+		for _, row := range rows {
+			seen = append(seen, row["id"])
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(seen, []interface{}{int64(1), int64(2), int64(3)}) {
+		t.Errorf("unexpected rows %v", seen)
+	}
+
+	assertCalls(t, server,
+		fakedb.Call{Query: "select * from \"users\" where \"active\" = ? order by \"id\" asc limit 2", Args: args(int64(1))},
+		fakedb.Call{Query: "select * from \"users\" where \"active\" = ? order by \"id\" asc limit 2 offset 2", Args: args(int64(1))},
+	)
+}
+
+func TestChunkStopsOnCallbackError(t *testing.T) {
+
+	server, connection := newFakeConnection()
+
+	server.QueueRows([]string{"id"}, []driver.Value{int64(1)}, []driver.Value{int64(2)})
+
+	stop := errors.New("stop")
+	err := connection.Table("users").OrderBy("id", "asc").Chunk(2, func(rows []map[string]interface{}) error {
+		return stop
+	})
+	if err != stop {
+		t.Errorf("expected the callback error, got %v", err)
+	}
+
+	if len(server.Calls()) != 1 {
+		t.Errorf("expected a single query, got %v", server.Queries())
+	}
+
+	if err := connection.Table("users").OrderBy("id", "asc").Chunk(0, nil); err == nil {
+		t.Error("expected a zero chunk size to fail")
+	}
+
+	if err := connection.Table("users").Chunk(10, nil); err == nil {
+		t.Error("expected chunking without an order to fail")
+	}
+}
+
+func TestChunkById(t *testing.T) {
+
+	server, connection := newFakeConnection()
+
+	server.QueueRows([]string{"id"}, []driver.Value{int64(1)}, []driver.Value{int64(2)})
+	server.QueueRows([]string{"id"}, []driver.Value{int64(3)})
+
+	chunks := 0
+	err := connection.Table("users").Where("active", 1).OrWhere("admin", 1).OrderBy("name", "desc").
+		ChunkById(2, "id", func(rows []map[string]interface{}) error {
+			chunks++
+			return nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if chunks != 2 {
+		t.Errorf("expected 2 chunks, got %d", chunks)
+	}
+
+	assertCalls(t, server,
+		fakedb.Call{
+			Query: "select * from \"users\" where \"active\" = ? or \"admin\" = ? order by \"id\" asc limit 2",
+			Args:  args(int64(1), int64(1)),
+		},
+		fakedb.Call{
+			Query: "select * from \"users\" where (\"active\" = ? or \"admin\" = ?) and \"id\" > ? order by \"id\" asc limit 2",
+			Args:  args(int64(1), int64(1), int64(2)),
+		},
+	)
+}
+
+func TestChunkByIdRequiresTheColumnInResults(t *testing.T) {
+
+	server, connection := newFakeConnection()
+
+	server.QueueRows([]string{"name"}, []driver.Value{"Jane"}, []driver.Value{"John"})
+
+	err := connection.Table("users").Select("name").ChunkById(2, "id", func(rows []map[string]interface{}) error {
+		return nil
+	})
+	if err == nil {
+		t.Error("expected a missing id column to abort the chunk")
+	}
+}
+
+func TestPaginateCountsWithoutSelectBindings(t *testing.T) {
+
+	server, connection := newFakeConnection()
+
+	server.QueueRows([]string{"aggregate"}, []driver.Value{int64(3)})
+	server.QueueRows([]string{"id", "next_age"}, []driver.Value{int64(3), int64(35)})
+
+	paginator, err := connection.Table("users").SelectRaw("id, age + ? as next_age", 5).
+		Where("active", 1).OrderBy("id", "asc").Paginate(2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if paginator.Total != 3 || paginator.LastPage != 2 || len(paginator.Items) != 1 {
+		t.Errorf("unexpected paginator %+v", paginator)
+	}
+
+	assertCalls(t, server,
+		fakedb.Call{
+			Query: "select count(*) as aggregate from \"users\" where \"active\" = ?",
+			Args:  args(int64(1)),
+		},
+		fakedb.Call{
+			Query: "select id, age + ? as next_age from \"users\" where \"active\" = ? order by \"id\" asc limit 2 offset 2",
+			Args:  args(int64(5), int64(1)),
+		},
+	)
+}
+
+func TestPaginateCountsGroupedQueriesInASubQuery(t *testing.T) {
+
+	server, connection := newFakeConnection()
+
+	server.QueueRows([]string{"aggregate"}, []driver.Value{int64(0)})
+
+	_, err := connection.Table("users").SelectRaw("age + ? as next_age", 5).GroupBy("next_age").Paginate(2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertCalls(t, server, fakedb.Call{
+		Query: "select count(*) as aggregate from (select age + ? as next_age from \"users\" group by \"next_age\") as \"temp_table\"",
+		Args:  args(int64(5)),
+	})
+}
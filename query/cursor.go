@@ -0,0 +1,51 @@
+package query
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+)
+
+type Cursor struct {
+	rows    *sql.Rows
+	columns []string
+}
+
+func newCursor(rows *sql.Rows) (*Cursor, error) {
+
+	columns, err := rows.Columns()
+	if err != nil {
+		rows.Close()
+		return nil, err
+	}
+
+	return &Cursor{
+		rows:    rows,
+		columns: columns,
+	}, nil
+}
+
+func (c *Cursor) Next() bool {
+
+	return c.rows.Next()
+}
+
+func (c *Cursor) Scan(dest interface{}) error {
+
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return errors.New("Scan destination must be a non-nil pointer")
+	}
+
+	return scanRow(c.rows, c.columns, value.Elem())
+}
+
+func (c *Cursor) Err() error {
+
+	return c.rows.Err()
+}
+
+func (c *Cursor) Close() error {
+
+	return c.rows.Close()
+}
@@ -0,0 +1,48 @@
+package query_test
+
+import (
+	"database/internal/fakedb"
+	"database/sql/driver"
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+
+	server, connection := newFakeConnection()
+
+	server.QueueRows([]string{"id", "full_name"},
+		[]driver.Value{int64(1), "Jane"},
+		[]driver.Value{int64(2), "John"},
+	)
+
+	cursor, err := connection.Table("users").Where("id", ">", 0).Cursor()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer cursor.Close()
+
+	var names []string
+	for cursor.Next() {
+		var row user
+		if err := cursor.Scan(&row); err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, row.Name)
+	}
+
+	if err := cursor.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(names) != 2 || names[0] != "Jane" || names[1] != "John" {
+		t.Errorf("unexpected names %v", names)
+	}
+
+	var invalid user
+	if err := cursor.Scan(invalid); err == nil {
+		t.Error("expected an error for a non-pointer destination")
+	}
+
+	assertCalls(t, server, fakedb.Call{Query: "select * from \"users\" where \"id\" > ?", Args: args(int64(0))})
+}
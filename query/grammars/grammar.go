@@ -4,21 +4,48 @@ import (
 	"database/contracts"
 	"database/query"
 	"database/query/types"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 )
 
 type Grammar struct {
-	parametrizeSymbol string
-	selectComponents  map[int]interface{}
+	returning          bool
+	insertOrIgnore     bool
+	transactionOptions bool
+	readOnly           bool
+	lockModifiers      bool
+	parametrizeSymbol  string
+	parametrizeFormat  string
+	wrapLeft           string
+	wrapRight          string
+	selectComponents   map[int]interface{}
+	whereComponents    map[string]interface{}
+	literalComponents  map[string]interface{}
 }
 
 func NewGrammar() *Grammar {
 
-	return &Grammar{
-		parametrizeSymbol: "?",
-		selectComponents:  map[int]interface{}{},
+	g := &Grammar{
+		insertOrIgnore:     true,
+		transactionOptions: true,
+		readOnly:           true,
+		lockModifiers:      true,
+		parametrizeSymbol:  "?",
+		parametrizeFormat:  "",
+		wrapLeft:           "`",
+		wrapRight:          "`",
+		selectComponents:   map[int]interface{}{},
 	}
+
+	g.whereComponents = g.GetDefaultWhereComponents()
+	g.literalComponents = g.GetDefaultLiteralComponents()
+
+	return g
 }
 
 func (g *Grammar) GetDefaultSelectComponents() map[int]interface{} {
@@ -39,16 +66,104 @@ func (g *Grammar) GetDefaultSelectComponents() map[int]interface{} {
 	}
 }
 
+func (g *Grammar) GetDefaultWhereComponents() map[string]interface{} {
+
+	return map[string]interface{}{
+		"basic": g.whereBasic,
+		"date":  g.whereDate,
+	}
+}
+
+func (g *Grammar) GetDefaultLiteralComponents() map[string]interface{} {
+
+	return map[string]interface{}{
+		"string": g.escapeString,
+		"bool":   g.escapeBool,
+		"binary": g.escapeBinary,
+	}
+}
+
 func (g *Grammar) SetSelectComponents(m map[int]interface{}) {
 
 	g.selectComponents = m
 }
 
+func (g *Grammar) SetWhereComponents(m map[string]interface{}) {
+
+	g.whereComponents = m
+}
+
+func (g *Grammar) SetLiteralComponents(m map[string]interface{}) {
+
+	g.literalComponents = m
+}
+
 func (g *Grammar) SetParametrizeSymbol(s string) {
 
 	g.parametrizeSymbol = s
 }
 
+func (g *Grammar) SetParametrizeFormat(f string) {
+
+	g.parametrizeFormat = f
+}
+
+func (g *Grammar) SetReturning(returning bool) {
+
+	g.returning = returning
+}
+
+func (g *Grammar) SupportsReturning() bool {
+
+	return g.returning
+}
+
+func (g *Grammar) SetInsertOrIgnore(supports bool) {
+
+	g.insertOrIgnore = supports
+}
+
+func (g *Grammar) SupportsInsertOrIgnore() bool {
+
+	return g.insertOrIgnore
+}
+
+func (g *Grammar) SetTransactionOptions(supports bool) {
+
+	g.transactionOptions = supports
+}
+
+func (g *Grammar) SupportsTransactionOptions() bool {
+
+	return g.transactionOptions
+}
+
+func (g *Grammar) SetReadOnlyTransactions(supports bool) {
+
+	g.readOnly = supports
+}
+
+func (g *Grammar) SupportsReadOnlyTransactions() bool {
+
+	return g.readOnly
+}
+
+func (g *Grammar) SetLockModifiers(supports bool) {
+
+	g.lockModifiers = supports
+}
+
+func (g *Grammar) SupportsLockModifiers() bool {
+
+	return g.lockModifiers
+}
+
+func (g *Grammar) SetWrapSymbols(left string, right string) {
+
+	g.wrapLeft = left
+	g.wrapRight = right
+}
+
 func (g *Grammar) CompileSelect(b contracts.QueryBuilder) string {
 
 	var queryBuilder = b.(*query.Builder)
@@ -85,9 +200,16 @@ func (g *Grammar) compileAggregate(b contracts.QueryBuilder, queryBuilder *query
 		return ""
 	}
 
+	columns := strings.Split(queryBuilder.Aggregate.GetColumns(), ", ")
+
+	column := g.columnize(columns)
+	if queryBuilder.IsDistinct && column != "*" {
+		column = "distinct " + column
+	}
+
 	return fmt.Sprintf("select %s(%s) as aggregate",
 		queryBuilder.Aggregate.GetFunction(),
-		g.Wrap(queryBuilder.Aggregate.GetColumns()),
+		column,
 	)
 }
 
@@ -109,6 +231,10 @@ func (g *Grammar) compileColumns(b contracts.QueryBuilder, queryBuilder *query.B
 		}
 	}
 
+	if queryBuilder.IsDistinct {
+		return "select distinct " + strings.Join(res, ", ")
+	}
+
 	return "select " + strings.Join(res, ", ")
 }
 
@@ -149,7 +275,7 @@ func (g *Grammar) compileWhere(b contracts.QueryBuilder, queryBuilder *query.Bui
 	for _, w := range queryBuilder.Wheres {
 		switch w.(type) {
 		default:
-			condition := fmt.Sprintf("%v %v %v", g.Wrap(w.GetColumn()), w.GetOperator(), g.parameterizeWhere(w, ", "))
+			condition := g.whereComponents["basic"].(func(types.WhereType) string)(w)
 			res = append(res, w.GetLogic()+" "+condition)
 			break
 		case *types.WhereIn:
@@ -172,13 +298,7 @@ func (g *Grammar) compileWhere(b contracts.QueryBuilder, queryBuilder *query.Bui
 			res = append(res, w.GetLogic()+" "+condition)
 			break
 		case *types.WhereDate:
-			where := w.(types.WhereDateType)
-			condition := fmt.Sprintf("%v(%v) %v %v",
-				where.GetDateType(),
-				g.Wrap(w.GetColumn()),
-				w.GetOperator(),
-				g.parameterizeWhere(w, ", "),
-			)
+			condition := g.whereComponents["date"].(func(types.WhereType) string)(w)
 			res = append(res, w.GetLogic()+" "+condition)
 			break
 		case *types.WhereNested:
@@ -204,6 +324,23 @@ func (g *Grammar) compileWhere(b contracts.QueryBuilder, queryBuilder *query.Bui
 	return conjunction + " " + g.removeLeadingBoolean(strings.Join(res, " "))
 }
 
+func (g *Grammar) whereBasic(w types.WhereType) string {
+
+	return fmt.Sprintf("%v %v %v", g.Wrap(w.GetColumn()), w.GetOperator(), g.parameterizeWhere(w, ", "))
+}
+
+func (g *Grammar) whereDate(w types.WhereType) string {
+
+	where := w.(types.WhereDateType)
+
+	return fmt.Sprintf("%v(%v) %v %v",
+		where.GetDateType(),
+		g.Wrap(w.GetColumn()),
+		w.GetOperator(),
+		g.parameterizeWhere(w, ", "),
+	)
+}
+
 func (g *Grammar) compileGroups(b contracts.QueryBuilder, queryBuilder *query.Builder) string {
 
 	if len(queryBuilder.Groups) <= 0 {
@@ -322,6 +459,10 @@ func (g *Grammar) compileUnion(union types.UnionType) string {
 
 func (g *Grammar) compileLock(b contracts.QueryBuilder, queryBuilder *query.Builder) string {
 
+	if lock, ok := queryBuilder.RowLock.(types.ExpressionType); ok {
+		return lock.ValueToString()
+	}
+
 	return ""
 }
 
@@ -341,6 +482,42 @@ func (g *Grammar) CompileInsert(
 	return "insert into " + table + "(" + g.columnize(columns) + ") values " + strings.Join(params, ", ")
 }
 
+func (g *Grammar) CompileInsertGetId(
+	b contracts.QueryBuilder, values []map[string]interface{}, columns []string, sequence string,
+) string {
+
+	return g.CompileInsert(b, values, columns)
+}
+
+func (g *Grammar) CompileInsertOrIgnore(
+	b contracts.QueryBuilder, values []map[string]interface{}, columns []string,
+) string {
+
+	return g.CompileInsert(b, values, columns) + " on conflict do nothing"
+}
+
+func (g *Grammar) CompileUpsert(
+	b contracts.QueryBuilder, values []map[string]interface{}, columns []string, uniqueBy []string, update []string,
+) string {
+
+	sql := g.CompileInsert(b, values, columns)
+
+	var res []string
+	for _, col := range update {
+		res = append(res, g.Wrap(col)+" = "+g.Wrap("excluded."+col))
+	}
+
+	return sql + " on conflict (" + g.columnize(uniqueBy) + ") do update set " + strings.Join(res, ", ")
+}
+
+func (g *Grammar) CompileInsertUsing(b contracts.QueryBuilder, columns []string, sql string) string {
+
+	builder := b.(*query.Builder)
+	table := g.WrapTable(builder.Table)
+
+	return "insert into " + table + "(" + g.columnize(columns) + ") " + sql
+}
+
 func (g *Grammar) CompileUpdate(b contracts.QueryBuilder, values map[string]interface{}) string {
 
 	builder := b.(*query.Builder)
@@ -352,7 +529,7 @@ func (g *Grammar) CompileUpdate(b contracts.QueryBuilder, values map[string]inte
 	}
 
 	var columns []string
-	for col, _ := range values {
+	for _, col := range g.sortedColumns(values) {
 		columns = append(columns, g.Wrap(col)+" = "+g.parametrizeSymbol)
 	}
 
@@ -381,6 +558,43 @@ func (g *Grammar) CompileTruncate(b contracts.QueryBuilder) string {
 	return "truncate " + table
 }
 
+func (g *Grammar) CompileSetTransaction(opts *sql.TxOptions) string {
+
+	var modes []string
+
+	if level := g.compileIsolationLevel(opts.Isolation); len(level) > 0 {
+		modes = append(modes, "isolation level "+level)
+	}
+
+	if opts.ReadOnly {
+		modes = append(modes, "read only")
+	}
+
+	if len(modes) <= 0 {
+		return ""
+	}
+
+	return "set transaction " + strings.Join(modes, ", ")
+}
+
+func (g *Grammar) compileIsolationLevel(level sql.IsolationLevel) string {
+
+	switch level {
+	case sql.LevelReadUncommitted:
+		return "read uncommitted"
+	case sql.LevelReadCommitted:
+		return "read committed"
+	case sql.LevelRepeatableRead:
+		return "repeatable read"
+	case sql.LevelSnapshot:
+		return "snapshot"
+	case sql.LevelSerializable:
+		return "serializable"
+	default:
+		return ""
+	}
+}
+
 func (g *Grammar) CompileSavepoint(name string) string {
 
 	return "SAVEPOINT " + name
@@ -393,11 +607,12 @@ func (g *Grammar) CompileSavepointRollback(name string) string {
 
 func (g *Grammar) columnize(columns []string) string {
 
-	for i, v := range columns {
-		columns[i] = g.Wrap(v)
+	res := make([]string, 0, len(columns))
+	for _, v := range columns {
+		res = append(res, g.Wrap(v))
 	}
 
-	return strings.Join(columns, ", ")
+	return strings.Join(res, ", ")
 }
 
 func (g *Grammar) parameterize(values []interface{}, sep string) string {
@@ -481,7 +696,7 @@ func (g *Grammar) Wrap(v string) string {
 		return v
 	}
 
-	return "`" + v + "`"
+	return g.wrapLeft + v + g.wrapRight
 }
 
 func (g *Grammar) WrapTable(table interface{}) string {
@@ -496,6 +711,122 @@ func (g *Grammar) WrapTable(table interface{}) string {
 	panic("Wrong table params for wrap")
 }
 
+func (g *Grammar) SubstituteParameters(sql string) string {
+
+	if len(g.parametrizeFormat) <= 0 {
+		return sql
+	}
+
+	var res strings.Builder
+	var quote rune
+	n := 0
+
+	for _, r := range sql {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case string(r) == g.parametrizeSymbol:
+			n++
+			res.WriteString(fmt.Sprintf(g.parametrizeFormat, n))
+			continue
+		}
+
+		res.WriteRune(r)
+	}
+
+	return res.String()
+}
+
+func (g *Grammar) SubstituteBindingsIntoRawSql(sql string, bindings []interface{}) string {
+
+	var res strings.Builder
+	var quote rune
+	n := 0
+
+	for _, r := range sql {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case string(r) == g.parametrizeSymbol && n < len(bindings):
+			res.WriteString(g.EscapeValue(bindings[n]))
+			n++
+			continue
+		}
+
+		res.WriteRune(r)
+	}
+
+	return res.String()
+}
+
+func (g *Grammar) EscapeValue(value interface{}) string {
+
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			return g.literalComponents["string"].(func(string) string)(err.Error())
+		}
+
+		value = v
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return g.literalComponents["bool"].(func(bool) string)(v)
+	case string:
+		return g.literalComponents["string"].(func(string) string)(v)
+	case []byte:
+		return g.literalComponents["binary"].(func([]byte) string)(v)
+	case time.Time:
+		return g.literalComponents["string"].(func(string) string)(v.Format("2006-01-02 15:04:05.999999"))
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprintf("%v", v)
+	default:
+		return g.literalComponents["string"].(func(string) string)(fmt.Sprintf("%v", v))
+	}
+}
+
+func (g *Grammar) escapeString(value string) string {
+
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func (g *Grammar) escapeBool(value bool) string {
+
+	if value {
+		return "1"
+	}
+
+	return "0"
+}
+
+func (g *Grammar) escapeBinary(value []byte) string {
+
+	return "x'" + hex.EncodeToString(value) + "'"
+}
+
+func (g *Grammar) sortedColumns(values map[string]interface{}) []string {
+
+	columns := make([]string, 0, len(values))
+	for col := range values {
+		columns = append(columns, col)
+	}
+
+	sort.Strings(columns)
+
+	return columns
+}
+
 func (g *Grammar) PrepareBindingsForUpdate(
 	b contracts.QueryBuilder, bindings map[string][]interface{}, values map[string]interface{},
 ) []interface{} {
@@ -503,8 +834,8 @@ func (g *Grammar) PrepareBindingsForUpdate(
 	var res []interface{}
 	res = append(res, bindings["join"]...)
 
-	for _, v := range values {
-		res = append(res, v)
+	for _, col := range g.sortedColumns(values) {
+		res = append(res, values[col])
 	}
 
 	var queryBuilder = b.(*query.Builder)
@@ -0,0 +1,90 @@
+package grammars
+
+import (
+	"database/contracts"
+	"database/query"
+	"database/query/types"
+	"reflect"
+	"testing"
+)
+
+type sqlCase struct {
+	name     string
+	sql      string
+	expected string
+	bindings []interface{}
+	actual   []interface{}
+}
+
+var upsertValues = []map[string]interface{}{{"email": "a@b.c", "name": "x"}}
+
+func newBuilder(g contracts.Grammar) contracts.QueryBuilder {
+
+	return query.NewBuilder(nil, g)
+}
+
+func selectCase(name string, b contracts.QueryBuilder, expected string, bindings ...interface{}) sqlCase {
+
+	return sqlCase{
+		name:     name,
+		sql:      b.ToSql(),
+		expected: expected,
+		bindings: bindings,
+		actual:   b.GetBindingsForSql(),
+	}
+}
+
+func withAggregate(b contracts.QueryBuilder, function string, column string) contracts.QueryBuilder {
+
+	b.(*query.Builder).Aggregate = types.NewAggregate(function, column)
+
+	return b
+}
+
+func rawCase(name string, sql string, expected string) sqlCase {
+
+	return sqlCase{name: name, sql: sql, expected: expected}
+}
+
+func runSqlCases(t *testing.T, cases []sqlCase) {
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if c.sql != c.expected {
+				t.Errorf("sql mismatch\n got: %s\nwant: %s", c.sql, c.expected)
+			}
+
+			if len(c.bindings) > 0 && !reflect.DeepEqual(c.actual, c.bindings) {
+				t.Errorf("bindings mismatch\n got: %v\nwant: %v", c.actual, c.bindings)
+			}
+		})
+	}
+}
+
+func TestBuilderReturnsRecordedErrors(t *testing.T) {
+
+	q := func() contracts.QueryBuilder { return newBuilder(NewMysqlGrammar()).From("users") }
+
+	builders := map[string]contracts.QueryBuilder{
+		"where":        q().Where("id", struct{}{}),
+		"nested where": q().Where(func(n contracts.QueryBuilder) { n.Where("id", struct{}{}) }),
+		"sub query":    q().FromSub(42, "t"),
+		"union":        q().Union(q().Where("id", []int{1})),
+	}
+
+	for name, b := range builders {
+		t.Run(name, func(t *testing.T) {
+			if _, err := b.Get(); err == nil {
+				t.Error("expected Get to return the recorded error")
+			}
+
+			if _, err := b.Update(map[string]interface{}{"name": "n"}); err == nil {
+				t.Error("expected Update to return the recorded error")
+			}
+
+			if _, err := b.Delete(); err == nil {
+				t.Error("expected Delete to return the recorded error")
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 type MysqlGrammar struct {
 	*Grammar
+	upsertAlias bool
 }
 
 func NewMysqlGrammar() *MysqlGrammar {
@@ -18,7 +19,9 @@ func NewMysqlGrammar() *MysqlGrammar {
 	}
 
 	mg.Grammar.SetParametrizeSymbol("?")
+	mg.Grammar.SetWrapSymbols("`", "`")
 	mg.Grammar.SetSelectComponents(mg.GetMysqlSelectComponents())
+	mg.Grammar.SetLiteralComponents(mg.GetMysqlLiteralComponents())
 
 	return mg
 }
@@ -40,6 +43,20 @@ func (g *MysqlGrammar) GetMysqlSelectComponents() map[int]interface{} {
 	}
 }
 
+func (g *MysqlGrammar) GetMysqlLiteralComponents() map[string]interface{} {
+
+	return map[string]interface{}{
+		"string": g.escapeString,
+		"bool":   g.escapeBool,
+		"binary": g.escapeBinary,
+	}
+}
+
+func (g *MysqlGrammar) SetUpsertAlias(alias bool) {
+
+	g.upsertAlias = alias
+}
+
 func (g *MysqlGrammar) CompileSelect(b contracts.QueryBuilder) string {
 
 	sql := g.Grammar.CompileSelect(b)
@@ -54,6 +71,30 @@ func (g *MysqlGrammar) CompileSelect(b contracts.QueryBuilder) string {
 	return sql
 }
 
+func (g *MysqlGrammar) compileLock(b contracts.QueryBuilder, queryBuilder *query.Builder) string {
+
+	lock := queryBuilder.RowLock
+
+	if lock == nil || lock.GetMode() == "raw" {
+		return g.Grammar.compileLock(b, queryBuilder)
+	}
+
+	if lock.GetMode() == "update" {
+		return strings.TrimRight("for update "+lock.GetModifier(), " ")
+	}
+
+	if len(lock.GetModifier()) > 0 {
+		return "for share " + lock.GetModifier()
+	}
+
+	return "lock in share mode"
+}
+
+func (g *MysqlGrammar) escapeString(value string) string {
+
+	return g.Grammar.escapeString(strings.ReplaceAll(value, "\\", "\\\\"))
+}
+
 func (g *MysqlGrammar) compileUnions(b contracts.QueryBuilder, queryBuilder *query.Builder) string {
 
 	sql := ""
@@ -101,6 +142,35 @@ func (g *MysqlGrammar) compileUnion(union types.UnionType) string {
 	return conjunction + "(" + union.GetValue().ToSql() + ")"
 }
 
+func (g *MysqlGrammar) CompileInsertOrIgnore(
+	b contracts.QueryBuilder, values []map[string]interface{}, columns []string,
+) string {
+
+	return strings.Replace(g.CompileInsert(b, values, columns), "insert", "insert ignore", 1)
+}
+
+func (g *MysqlGrammar) CompileUpsert(
+	b contracts.QueryBuilder, values []map[string]interface{}, columns []string, uniqueBy []string, update []string,
+) string {
+
+	sql := g.CompileInsert(b, values, columns)
+
+	if g.upsertAlias {
+		sql += " as " + g.Wrap("upsert_alias")
+	}
+
+	var res []string
+	for _, col := range update {
+		if g.upsertAlias {
+			res = append(res, g.Wrap(col)+" = "+g.Wrap("upsert_alias."+col))
+		} else {
+			res = append(res, g.Wrap(col)+" = values("+g.Wrap(col)+")")
+		}
+	}
+
+	return sql + " on duplicate key update " + strings.Join(res, ", ")
+}
+
 func (g *MysqlGrammar) CompileUpdate(b contracts.QueryBuilder, values map[string]interface{}) string {
 
 	builder := b.(*query.Builder)
@@ -112,7 +182,7 @@ func (g *MysqlGrammar) CompileUpdate(b contracts.QueryBuilder, values map[string
 	}
 
 	var columns []string
-	for col, _ := range values {
+	for _, col := range g.sortedColumns(values) {
 		columns = append(columns, g.Wrap(col)+" = "+g.parametrizeSymbol)
 	}
 
@@ -164,7 +234,7 @@ func (g *MysqlGrammar) compileDeleteWithJoins(
 
 	joins := " " + g.compileJoins(b, builder)
 
-	return strings.Trim("delete from "+table+joins+" "+wheres, " ")
+	return strings.Trim("delete "+table+" from "+table+joins+" "+wheres, " ")
 }
 
 func (g *MysqlGrammar) compileDeleteWithoutJoins(
@@ -0,0 +1,182 @@
+package grammars
+
+import (
+	"database/contracts"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMysqlGrammarSelect(t *testing.T) {
+
+	g := NewMysqlGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g) }
+
+	runSqlCases(t, []sqlCase{
+		selectCase("select",
+			q().From("users").Select("id", "name").Where("id", ">", 1).OrderBy("name", "asc"),
+			"select `id`, `name` from `users` where `id` > ? order by `name` asc", 1),
+		selectCase("limit", q().From("users").Limit(10),
+			"select * from `users` limit 10"),
+		selectCase("limit offset", q().From("users").Limit(10).Offset(5),
+			"select * from `users` limit 10 offset 5"),
+	})
+}
+
+func TestMysqlGrammarUpdateAndDeleteWithJoins(t *testing.T) {
+
+	g := NewMysqlGrammar()
+	q := func() contracts.QueryBuilder {
+		return newBuilder(g).From("users").Join("posts", "users.id", "=", "posts.user_id").Where("posts.id", 7)
+	}
+
+	update := q()
+	updateValues := map[string]interface{}{"name": "n", "age": 3}
+
+	runSqlCases(t, []sqlCase{
+		{
+			name:     "update with joins",
+			sql:      g.CompileUpdate(update, updateValues),
+			expected: "update `users` inner join `posts` on `users`.`id` = `posts`.`user_id` set `age` = ?, `name` = ? where `posts`.`id` = ?",
+			bindings: []interface{}{3, "n", 7},
+			actual:   g.PrepareBindingsForUpdate(update, map[string][]interface{}{}, updateValues),
+		},
+		rawCase("delete with joins", g.CompileDelete(q()),
+			"delete `users` from `users` inner join `posts` on `users`.`id` = `posts`.`user_id` where `posts`.`id` = ?"),
+	})
+}
+
+func TestMysqlGrammarWhereDate(t *testing.T) {
+
+	g := NewMysqlGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g).From("users") }
+
+	runSqlCases(t, []sqlCase{
+		selectCase("year as int", q().WhereYear("created_at", 2020),
+			"select * from `users` where year(`created_at`) = ?", 2020),
+		selectCase("day as int", q().WhereDay("created_at", ">", 5),
+			"select * from `users` where day(`created_at`) > ?", 5),
+	})
+}
+
+func TestMysqlGrammarDistinctAndUnions(t *testing.T) {
+
+	g := NewMysqlGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g).From("users") }
+
+	runSqlCases(t, []sqlCase{
+		selectCase("distinct", q().Distinct().Select("name"),
+			"select distinct `name` from `users`"),
+		selectCase("distinct count", withAggregate(q().Distinct(), "count", "name"),
+			"select count(distinct `name`) as aggregate from `users`"),
+		selectCase("union", q().Where("a", 1).Union(newBuilder(g).From("admins").Where("b", 2)),
+			"(select * from `users` where `a` = ?) union (select * from `admins` where `b` = ?)", 1, 2),
+		selectCase("union all", q().UnionAll(newBuilder(g).From("admins")),
+			"(select * from `users`) union all (select * from `admins`)"),
+	})
+}
+
+func TestMysqlGrammarDump(t *testing.T) {
+
+	var out strings.Builder
+	newBuilder(NewMysqlGrammar()).From("users").Where("name", "o'k").Dump(&out)
+
+	expected := "select * from `users` where `name` = 'o''k'\n"
+	if out.String() != expected {
+		t.Errorf("dump mismatch\n got: %q\nwant: %q", out.String(), expected)
+	}
+}
+
+func TestMysqlGrammarUpsertAndInsertOrIgnore(t *testing.T) {
+
+	g := NewMysqlGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g).From("users") }
+
+	aliased := NewMysqlGrammar()
+	aliased.SetUpsertAlias(true)
+
+	columns := []string{"email", "name"}
+
+	runSqlCases(t, []sqlCase{
+		rawCase("upsert", g.CompileUpsert(q(), upsertValues, columns, []string{"email"}, []string{"name"}),
+			"insert into `users`(`email`, `name`) values (?, ?) on duplicate key update `name` = values(`name`)"),
+		rawCase("upsert with alias",
+			aliased.CompileUpsert(newBuilder(aliased).From("users"), upsertValues, columns, []string{"email"}, []string{"name"}),
+			"insert into `users`(`email`, `name`) values (?, ?) as `upsert_alias` on duplicate key update `name` = `upsert_alias`.`name`"),
+		rawCase("insert or ignore", g.CompileInsertOrIgnore(q(), upsertValues, columns),
+			"insert ignore into `users`(`email`, `name`) values (?, ?)"),
+		rawCase("insert using", g.CompileInsertUsing(q(), columns, newBuilder(g).From("admins").Select("email", "name").ToSql()),
+			"insert into `users`(`email`, `name`) select `email`, `name` from `admins`"),
+	})
+}
+
+func TestMysqlGrammarInsertGetId(t *testing.T) {
+
+	g := NewMysqlGrammar()
+
+	runSqlCases(t, []sqlCase{
+		rawCase("insert get id", g.CompileInsertGetId(newBuilder(g).From("users"), upsertValues, []string{"email", "name"}, "id"),
+			"insert into `users`(`email`, `name`) values (?, ?)"),
+	})
+
+	if g.SupportsReturning() {
+		t.Error("mysql must not claim returning support")
+	}
+}
+
+func TestMysqlGrammarLocks(t *testing.T) {
+
+	g := NewMysqlGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g).From("users").Where("id", 1) }
+
+	runSqlCases(t, []sqlCase{
+		selectCase("lock for update", q().LockForUpdate(),
+			"select * from `users` where `id` = ? for update", 1),
+		selectCase("shared lock", q().SharedLock(),
+			"select * from `users` where `id` = ? lock in share mode", 1),
+		selectCase("skip locked", q().LockForUpdate().SkipLocked(),
+			"select * from `users` where `id` = ? for update skip locked", 1),
+		selectCase("shared nowait", q().SharedLock().NoWait(),
+			"select * from `users` where `id` = ? for share nowait", 1),
+		selectCase("raw lock", q().Lock("for update of `users`"),
+			"select * from `users` where `id` = ? for update of `users`", 1),
+	})
+}
+
+func TestMysqlGrammarRawSql(t *testing.T) {
+
+	g := NewMysqlGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g).From("users") }
+
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	runSqlCases(t, []sqlCase{
+		rawCase("escaped string", q().Where("name", "o'k\\").ToRawSql(),
+			"select * from `users` where `name` = 'o''k\\\\'"),
+		rawCase("literals", q().Where("active", true).Where("deleted_at", "<", created).Where("hash", []byte{0xde, 0xad}).ToRawSql(),
+			"select * from `users` where `active` = 1 and `deleted_at` < '2020-01-02 03:04:05' and `hash` = x'dead'"),
+		rawCase("placeholder inside a quoted literal", q().WhereRaw("`name` = '?' and `id` = ?", 1).ToRawSql(),
+			"select * from `users` where `name` = '?' and `id` = 1"),
+		rawCase("insert", q().ToRawInsertSql(map[string]interface{}{"name": "x", "parent_id": nil}),
+			"insert into `users`(`name`, `parent_id`) values ('x', null)"),
+		rawCase("update", q().Where("id", 1).ToRawUpdateSql(map[string]interface{}{"name": "n", "age": 3}),
+			"update `users` set `age` = 3, `name` = 'n' where `id` = 1"),
+		rawCase("delete", q().Where("id", 1).ToRawDeleteSql(),
+			"delete from `users` where `id` = 1"),
+	})
+}
+
+func TestMysqlGrammarTransactions(t *testing.T) {
+
+	g := NewMysqlGrammar()
+
+	runSqlCases(t, []sqlCase{
+		rawCase("set transaction", g.CompileSetTransaction(&sql.TxOptions{Isolation: sql.LevelReadCommitted, ReadOnly: true}),
+			"set transaction isolation level read committed, read only"),
+	})
+
+	if !g.SupportsTransactionOptions() || !g.SupportsReadOnlyTransactions() {
+		t.Error("mysql must pass transaction options through sql.TxOptions")
+	}
+}
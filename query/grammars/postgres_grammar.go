@@ -0,0 +1,252 @@
+package grammars
+
+import (
+	"database/contracts"
+	"database/query"
+	"database/query/types"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+type PostgresGrammar struct {
+	*Grammar
+}
+
+func NewPostgresGrammar() *PostgresGrammar {
+
+	var pg = &PostgresGrammar{
+		Grammar: NewGrammar(),
+	}
+
+	pg.Grammar.SetReturning(true)
+	pg.Grammar.SetParametrizeSymbol("?")
+	pg.Grammar.SetParametrizeFormat("$%d")
+	pg.Grammar.SetWrapSymbols("\"", "\"")
+	pg.Grammar.SetSelectComponents(pg.GetPostgresSelectComponents())
+	pg.Grammar.SetWhereComponents(pg.GetPostgresWhereComponents())
+	pg.Grammar.SetLiteralComponents(pg.GetPostgresLiteralComponents())
+
+	return pg
+}
+
+func (g *PostgresGrammar) GetPostgresSelectComponents() map[int]interface{} {
+
+	return map[int]interface{}{
+		0:  g.compileAggregate,
+		1:  g.compileColumns,
+		2:  g.compileFrom,
+		3:  g.compileJoins,
+		4:  g.compileWhere,
+		5:  g.compileGroups,
+		6:  g.compileHavings,
+		7:  g.compileOrders,
+		8:  g.compileLimit,
+		9:  g.compileOffset,
+		10: g.compileLock,
+	}
+}
+
+func (g *PostgresGrammar) GetPostgresWhereComponents() map[string]interface{} {
+
+	return map[string]interface{}{
+		"basic": g.whereBasic,
+		"date":  g.whereDate,
+	}
+}
+
+func (g *PostgresGrammar) GetPostgresLiteralComponents() map[string]interface{} {
+
+	return map[string]interface{}{
+		"string": g.escapeString,
+		"bool":   g.escapeBool,
+		"binary": g.escapeBinary,
+	}
+}
+
+func (g *PostgresGrammar) CompileSelect(b contracts.QueryBuilder) string {
+
+	sql := g.Grammar.CompileSelect(b)
+
+	queryBuilder := b.(*query.Builder)
+
+	if len(queryBuilder.Unions) > 0 {
+
+		sql = "(" + sql + ") " + g.compileUnions(b, queryBuilder)
+	}
+
+	return sql
+}
+
+func (g *PostgresGrammar) compileLock(b contracts.QueryBuilder, queryBuilder *query.Builder) string {
+
+	lock := queryBuilder.RowLock
+
+	if lock == nil || lock.GetMode() == "raw" {
+		return g.Grammar.compileLock(b, queryBuilder)
+	}
+
+	return strings.TrimRight("for "+lock.GetMode()+" "+lock.GetModifier(), " ")
+}
+
+func (g *PostgresGrammar) escapeBool(value bool) string {
+
+	if value {
+		return "true"
+	}
+
+	return "false"
+}
+
+func (g *PostgresGrammar) escapeBinary(value []byte) string {
+
+	return "'\\x" + hex.EncodeToString(value) + "'::bytea"
+}
+
+func (g *PostgresGrammar) compileUnions(b contracts.QueryBuilder, queryBuilder *query.Builder) string {
+
+	sql := ""
+	for _, v := range queryBuilder.Unions {
+		sql += g.compileUnion(v)
+	}
+
+	if len(queryBuilder.UnionOrders) > 0 {
+		orderTmp := queryBuilder.Orders
+		queryBuilder.Orders = queryBuilder.UnionOrders
+
+		sql += " " + g.compileOrders(b, queryBuilder)
+
+		queryBuilder.Orders = orderTmp
+	}
+
+	if queryBuilder.UnionLimit > 0 {
+		limitTmp := queryBuilder.RowLimit
+		queryBuilder.RowLimit = queryBuilder.UnionLimit
+
+		sql += " " + g.compileLimit(b, queryBuilder)
+
+		queryBuilder.RowLimit = limitTmp
+	}
+
+	if queryBuilder.UnionOffset > 0 {
+		offsetTmp := queryBuilder.RowOffset
+		queryBuilder.RowOffset = queryBuilder.UnionOffset
+
+		sql += " " + g.compileOffset(b, queryBuilder)
+
+		queryBuilder.RowOffset = offsetTmp
+	}
+
+	return strings.TrimLeft(sql, " ")
+}
+
+func (g *PostgresGrammar) compileUnion(union types.UnionType) string {
+
+	conjunction := " union "
+	if union.IsAll() {
+		conjunction = " union all "
+	}
+
+	return conjunction + "(" + union.GetValue().ToSql() + ")"
+}
+
+func (g *PostgresGrammar) whereBasic(w types.WhereType) string {
+
+	operator := strings.ToLower(w.GetOperator())
+
+	if strings.Contains(operator, "like") {
+		return fmt.Sprintf("%v::text %v %v", g.Wrap(w.GetColumn()), operator, g.parameterizeWhere(w, ", "))
+	}
+
+	return g.Grammar.whereBasic(w)
+}
+
+func (g *PostgresGrammar) whereDate(w types.WhereType) string {
+
+	where := w.(types.WhereDateType)
+	column := g.Wrap(w.GetColumn())
+
+	switch where.GetDateType() {
+	case "date", "time":
+		column = column + "::" + where.GetDateType()
+		break
+	default:
+		column = "extract(" + where.GetDateType() + " from " + column + ")"
+		break
+	}
+
+	return fmt.Sprintf("%v %v %v", column, w.GetOperator(), g.parameterizeWhere(w, ", "))
+}
+
+func (g *PostgresGrammar) CompileInsertGetId(
+	b contracts.QueryBuilder, values []map[string]interface{}, columns []string, sequence string,
+) string {
+
+	return g.CompileInsert(b, values, columns) + " returning " + g.Wrap(sequence)
+}
+
+func (g *PostgresGrammar) CompileUpdate(b contracts.QueryBuilder, values map[string]interface{}) string {
+
+	builder := b.(*query.Builder)
+
+	if len(builder.Joins) <= 0 {
+		return g.Grammar.CompileUpdate(b, values)
+	}
+
+	table := g.WrapTable(builder.Table)
+
+	var columns []string
+	for _, col := range g.sortedColumns(values) {
+		columns = append(columns, g.Wrap(col)+" = "+g.parametrizeSymbol)
+	}
+
+	return "update " + table + " set " + strings.Join(columns, ", ") + " " + g.compileCtidSelect(b, builder, table)
+}
+
+func (g *PostgresGrammar) PrepareBindingsForUpdate(
+	b contracts.QueryBuilder, bindings map[string][]interface{}, values map[string]interface{},
+) []interface{} {
+
+	var res []interface{}
+	for _, col := range g.sortedColumns(values) {
+		res = append(res, values[col])
+	}
+
+	var queryBuilder = b.(*query.Builder)
+	exceptBindings := queryBuilder.GetBindingsForSql("select")
+
+	res = append(res, exceptBindings...)
+
+	return res
+}
+
+func (g *PostgresGrammar) CompileDelete(b contracts.QueryBuilder) string {
+
+	builder := b.(*query.Builder)
+
+	if len(builder.Joins) <= 0 {
+		return g.Grammar.CompileDelete(b)
+	}
+
+	table := g.WrapTable(builder.Table)
+
+	return "delete from " + table + " " + g.compileCtidSelect(b, builder, table)
+}
+
+func (g *PostgresGrammar) compileCtidSelect(b contracts.QueryBuilder, builder *query.Builder, table string) string {
+
+	joins := " " + g.compileJoins(b, builder)
+	wheres := g.compileWhere(b, builder)
+
+	sub := strings.Trim("select "+table+".ctid from "+table+joins+" "+wheres, " ")
+
+	return "where " + table + ".ctid in (" + sub + ")"
+}
+
+func (g *PostgresGrammar) CompileTruncate(b contracts.QueryBuilder) string {
+
+	builder := b.(*query.Builder)
+	table := g.WrapTable(builder.Table)
+
+	return "truncate " + table + " restart identity cascade"
+}
@@ -0,0 +1,117 @@
+package grammars
+
+import (
+	"database/contracts"
+	"testing"
+)
+
+func TestPostgresGrammarSelect(t *testing.T) {
+
+	g := NewPostgresGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g) }
+
+	runSqlCases(t, []sqlCase{
+		selectCase("select",
+			q().From("users").Select("id", "name").Where("id", ">", 1).OrderBy("name", "asc"),
+			`select "id", "name" from "users" where "id" > ? order by "name" asc`, 1),
+		selectCase("like", q().From("users").Where("name", "like", "a%"),
+			`select * from "users" where "name"::text like ?`, "a%"),
+		selectCase("limit offset", q().From("users").Limit(10).Offset(5),
+			`select * from "users" limit 10 offset 5`),
+		rawCase("parameters", g.SubstituteParameters(`select * from "users" where "a" = ? and "b" = '?' and "c" = ?`),
+			`select * from "users" where "a" = $1 and "b" = '?' and "c" = $2`),
+	})
+}
+
+func TestPostgresGrammarUpdateAndDeleteWithJoins(t *testing.T) {
+
+	g := NewPostgresGrammar()
+	q := func() contracts.QueryBuilder {
+		return newBuilder(g).From("users").Join("posts", "users.id", "=", "posts.user_id").Where("posts.id", 7)
+	}
+
+	update := q()
+	updateValues := map[string]interface{}{"name": "n", "age": 3}
+
+	runSqlCases(t, []sqlCase{
+		{
+			name:     "update with joins",
+			sql:      g.CompileUpdate(update, updateValues),
+			expected: `update "users" set "age" = ?, "name" = ? where "users".ctid in (select "users".ctid from "users" inner join "posts" on "users"."id" = "posts"."user_id" where "posts"."id" = ?)`,
+			bindings: []interface{}{3, "n", 7},
+			actual:   g.PrepareBindingsForUpdate(update, map[string][]interface{}{}, updateValues),
+		},
+		rawCase("delete with joins", g.CompileDelete(q()),
+			`delete from "users" where "users".ctid in (select "users".ctid from "users" inner join "posts" on "users"."id" = "posts"."user_id" where "posts"."id" = ?)`),
+	})
+}
+
+func TestPostgresGrammarDistinctAndUnions(t *testing.T) {
+
+	g := NewPostgresGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g).From("users") }
+
+	runSqlCases(t, []sqlCase{
+		selectCase("distinct", q().Distinct().Select("name"),
+			`select distinct "name" from "users"`),
+		selectCase("distinct count", withAggregate(q().Distinct(), "count", "name"),
+			`select count(distinct "name") as aggregate from "users"`),
+		selectCase("union", q().Where("a", 1).Union(newBuilder(g).From("admins").Where("b", 2)),
+			`(select * from "users" where "a" = ?) union (select * from "admins" where "b" = ?)`, 1, 2),
+	})
+}
+
+func TestPostgresGrammarUpsertAndInsertOrIgnore(t *testing.T) {
+
+	g := NewPostgresGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g).From("users") }
+
+	columns := []string{"email", "name"}
+
+	runSqlCases(t, []sqlCase{
+		rawCase("upsert", g.CompileUpsert(q(), upsertValues, columns, []string{"email"}, []string{"name"}),
+			`insert into "users"("email", "name") values (?, ?) on conflict ("email") do update set "name" = "excluded"."name"`),
+		rawCase("insert or ignore", g.CompileInsertOrIgnore(q(), upsertValues, columns),
+			`insert into "users"("email", "name") values (?, ?) on conflict do nothing`),
+	})
+}
+
+func TestPostgresGrammarInsertGetId(t *testing.T) {
+
+	g := NewPostgresGrammar()
+
+	runSqlCases(t, []sqlCase{
+		rawCase("insert get id", g.CompileInsertGetId(newBuilder(g).From("users"), upsertValues, []string{"email", "name"}, "id"),
+			`insert into "users"("email", "name") values (?, ?) returning "id"`),
+	})
+}
+
+func TestPostgresGrammarLocks(t *testing.T) {
+
+	g := NewPostgresGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g).From("users").Where("id", 1) }
+
+	runSqlCases(t, []sqlCase{
+		selectCase("lock for update", q().LockForUpdate(),
+			`select * from "users" where "id" = ? for update`, 1),
+		selectCase("shared lock", q().SharedLock(),
+			`select * from "users" where "id" = ? for share`, 1),
+		selectCase("nowait", q().LockForUpdate().NoWait(),
+			`select * from "users" where "id" = ? for update nowait`, 1),
+		selectCase("skip locked", q().LockForUpdate().SkipLocked(),
+			`select * from "users" where "id" = ? for update skip locked`, 1),
+	})
+}
+
+func TestPostgresGrammarRawSql(t *testing.T) {
+
+	g := NewPostgresGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g).From("users") }
+
+	runSqlCases(t, []sqlCase{
+		rawCase("literals", q().Where("active", true).Where("hash", []byte{0xde, 0xad}).ToRawSql(),
+			`select * from "users" where "active" = true and "hash" = '\xdead'::bytea`),
+		rawCase("escaped string", q().Where("name", "o'k\\").ToRawSql(),
+			`select * from "users" where "name" = 'o''k\'`),
+	})
+}
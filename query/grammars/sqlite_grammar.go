@@ -0,0 +1,228 @@
+package grammars
+
+import (
+	"database/contracts"
+	"database/query"
+	"database/query/types"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+type SqliteGrammar struct {
+	*Grammar
+}
+
+func NewSqliteGrammar() *SqliteGrammar {
+
+	var sg = &SqliteGrammar{
+		Grammar: NewGrammar(),
+	}
+
+	sg.Grammar.SetReturning(true)
+	sg.Grammar.SetTransactionOptions(false)
+	sg.Grammar.SetReadOnlyTransactions(false)
+	sg.Grammar.SetLockModifiers(false)
+	sg.Grammar.SetParametrizeSymbol("?")
+	sg.Grammar.SetWrapSymbols("\"", "\"")
+	sg.Grammar.SetSelectComponents(sg.GetSqliteSelectComponents())
+	sg.Grammar.SetWhereComponents(sg.GetSqliteWhereComponents())
+
+	return sg
+}
+
+func (g *SqliteGrammar) GetSqliteSelectComponents() map[int]interface{} {
+
+	return map[int]interface{}{
+		0:  g.compileAggregate,
+		1:  g.compileColumns,
+		2:  g.compileFrom,
+		3:  g.compileJoins,
+		4:  g.compileWhere,
+		5:  g.compileGroups,
+		6:  g.compileHavings,
+		7:  g.compileOrders,
+		8:  g.compileLimit,
+		9:  g.compileOffset,
+		10: g.compileLock,
+	}
+}
+
+func (g *SqliteGrammar) GetSqliteWhereComponents() map[string]interface{} {
+
+	return map[string]interface{}{
+		"basic": g.whereBasic,
+		"date":  g.whereDate,
+	}
+}
+
+func (g *SqliteGrammar) CompileSelect(b contracts.QueryBuilder) string {
+
+	sql := g.Grammar.CompileSelect(b)
+
+	queryBuilder := b.(*query.Builder)
+
+	if len(queryBuilder.Unions) > 0 {
+
+		sql = "select * from (" + sql + ") " + g.compileUnions(b, queryBuilder)
+	}
+
+	return sql
+}
+
+func (g *SqliteGrammar) compileLock(b contracts.QueryBuilder, queryBuilder *query.Builder) string {
+
+	return ""
+}
+
+func (g *SqliteGrammar) compileUnions(b contracts.QueryBuilder, queryBuilder *query.Builder) string {
+
+	sql := ""
+	for _, v := range queryBuilder.Unions {
+		sql += g.compileUnion(v)
+	}
+
+	if len(queryBuilder.UnionOrders) > 0 {
+		orderTmp := queryBuilder.Orders
+		queryBuilder.Orders = queryBuilder.UnionOrders
+
+		sql += " " + g.compileOrders(b, queryBuilder)
+
+		queryBuilder.Orders = orderTmp
+	}
+
+	if queryBuilder.UnionLimit > 0 {
+		limitTmp := queryBuilder.RowLimit
+		queryBuilder.RowLimit = queryBuilder.UnionLimit
+
+		sql += " " + g.compileLimit(b, queryBuilder)
+
+		queryBuilder.RowLimit = limitTmp
+	}
+
+	if queryBuilder.UnionOffset > 0 {
+		offsetTmp := queryBuilder.RowOffset
+		queryBuilder.RowOffset = queryBuilder.UnionOffset
+
+		sql += " " + g.compileOffset(b, queryBuilder)
+
+		queryBuilder.RowOffset = offsetTmp
+	}
+
+	return strings.TrimLeft(sql, " ")
+}
+
+func (g *SqliteGrammar) compileUnion(union types.UnionType) string {
+
+	conjunction := " union "
+	if union.IsAll() {
+		conjunction = " union all "
+	}
+
+	return conjunction + "select * from (" + union.GetValue().ToSql() + ")"
+}
+
+func (g *SqliteGrammar) whereDate(w types.WhereType) string {
+
+	where := w.(types.WhereDateType)
+
+	format := map[string]string{
+		"date":  "%Y-%m-%d",
+		"time":  "%H:%M:%S",
+		"day":   "%d",
+		"month": "%m",
+		"year":  "%Y",
+	}[where.GetDateType()]
+
+	return fmt.Sprintf("strftime('%v', %v) %v cast(%v as text)",
+		format,
+		g.Wrap(w.GetColumn()),
+		w.GetOperator(),
+		g.parameterizeWhere(w, ", "),
+	)
+}
+
+func (g *SqliteGrammar) CompileInsertGetId(
+	b contracts.QueryBuilder, values []map[string]interface{}, columns []string, sequence string,
+) string {
+
+	return g.CompileInsert(b, values, columns) + " returning " + g.Wrap(sequence)
+}
+
+func (g *SqliteGrammar) CompileInsertOrIgnore(
+	b contracts.QueryBuilder, values []map[string]interface{}, columns []string,
+) string {
+
+	return strings.Replace(g.CompileInsert(b, values, columns), "insert", "insert or ignore", 1)
+}
+
+func (g *SqliteGrammar) CompileUpdate(b contracts.QueryBuilder, values map[string]interface{}) string {
+
+	builder := b.(*query.Builder)
+
+	if len(builder.Joins) <= 0 {
+		return g.Grammar.CompileUpdate(b, values)
+	}
+
+	table := g.WrapTable(builder.Table)
+
+	var columns []string
+	for _, col := range g.sortedColumns(values) {
+		columns = append(columns, g.Wrap(col)+" = "+g.parametrizeSymbol)
+	}
+
+	return "update " + table + " set " + strings.Join(columns, ", ") + " " + g.compileRowidSelect(b, builder, table)
+}
+
+func (g *SqliteGrammar) PrepareBindingsForUpdate(
+	b contracts.QueryBuilder, bindings map[string][]interface{}, values map[string]interface{},
+) []interface{} {
+
+	var res []interface{}
+	for _, col := range g.sortedColumns(values) {
+		res = append(res, values[col])
+	}
+
+	var queryBuilder = b.(*query.Builder)
+	exceptBindings := queryBuilder.GetBindingsForSql("select")
+
+	res = append(res, exceptBindings...)
+
+	return res
+}
+
+func (g *SqliteGrammar) CompileDelete(b contracts.QueryBuilder) string {
+
+	builder := b.(*query.Builder)
+
+	if len(builder.Joins) <= 0 {
+		return g.Grammar.CompileDelete(b)
+	}
+
+	table := g.WrapTable(builder.Table)
+
+	return "delete from " + table + " " + g.compileRowidSelect(b, builder, table)
+}
+
+func (g *SqliteGrammar) compileRowidSelect(b contracts.QueryBuilder, builder *query.Builder, table string) string {
+
+	joins := " " + g.compileJoins(b, builder)
+	wheres := g.compileWhere(b, builder)
+
+	sub := strings.Trim("select "+table+".rowid from "+table+joins+" "+wheres, " ")
+
+	return "where " + table + ".rowid in (" + sub + ")"
+}
+
+func (g *SqliteGrammar) CompileSetTransaction(opts *sql.TxOptions) string {
+
+	return ""
+}
+
+func (g *SqliteGrammar) CompileTruncate(b contracts.QueryBuilder) string {
+
+	builder := b.(*query.Builder)
+	table := g.WrapTable(builder.Table)
+
+	return "delete from " + table
+}
@@ -0,0 +1,137 @@
+package grammars
+
+import (
+	"database/contracts"
+	"database/sql"
+	"testing"
+)
+
+func TestSqliteGrammarSelect(t *testing.T) {
+
+	g := NewSqliteGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g) }
+
+	runSqlCases(t, []sqlCase{
+		selectCase("select",
+			q().From("users").Select("id", "name").Where("id", ">", 1).OrderBy("name", "asc"),
+			`select "id", "name" from "users" where "id" > ? order by "name" asc`, 1),
+		selectCase("limit offset", q().From("users").Limit(10).Offset(5),
+			`select * from "users" limit 10 offset 5`),
+		selectCase("union", q().From("users").Where("a", 1).UnionAll(q().From("admins")),
+			`select * from (select * from "users" where "a" = ?) union all select * from (select * from "admins")`),
+		rawCase("truncate", g.CompileTruncate(q().From("users")),
+			`delete from "users"`),
+	})
+}
+
+func TestSqliteGrammarUpdateAndDeleteWithJoins(t *testing.T) {
+
+	g := NewSqliteGrammar()
+	q := func() contracts.QueryBuilder {
+		return newBuilder(g).From("users").Join("posts", "users.id", "=", "posts.user_id").Where("posts.id", 7)
+	}
+
+	update := q()
+	updateValues := map[string]interface{}{"name": "n", "age": 3}
+
+	runSqlCases(t, []sqlCase{
+		{
+			name:     "update with joins",
+			sql:      g.CompileUpdate(update, updateValues),
+			expected: `update "users" set "age" = ?, "name" = ? where "users".rowid in (select "users".rowid from "users" inner join "posts" on "users"."id" = "posts"."user_id" where "posts"."id" = ?)`,
+			bindings: []interface{}{3, "n", 7},
+			actual:   g.PrepareBindingsForUpdate(update, map[string][]interface{}{}, updateValues),
+		},
+		rawCase("delete with joins", g.CompileDelete(q()),
+			`delete from "users" where "users".rowid in (select "users".rowid from "users" inner join "posts" on "users"."id" = "posts"."user_id" where "posts"."id" = ?)`),
+	})
+}
+
+func TestSqliteGrammarDistinctAndUnions(t *testing.T) {
+
+	g := NewSqliteGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g).From("users") }
+
+	runSqlCases(t, []sqlCase{
+		selectCase("distinct count", withAggregate(q().Distinct(), "count", "name"),
+			`select count(distinct "name") as aggregate from "users"`),
+		selectCase("union", q().Where("a", 1).Union(newBuilder(g).From("admins").Where("b", 2)),
+			`select * from (select * from "users" where "a" = ?) union select * from (select * from "admins" where "b" = ?)`, 1, 2),
+	})
+}
+
+func TestSqliteGrammarUpsertAndInsertOrIgnore(t *testing.T) {
+
+	g := NewSqliteGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g).From("users") }
+
+	columns := []string{"email", "name"}
+
+	runSqlCases(t, []sqlCase{
+		rawCase("upsert", g.CompileUpsert(q(), upsertValues, columns, []string{"email"}, []string{"name"}),
+			`insert into "users"("email", "name") values (?, ?) on conflict ("email") do update set "name" = "excluded"."name"`),
+		rawCase("insert or ignore", g.CompileInsertOrIgnore(q(), upsertValues, columns),
+			`insert or ignore into "users"("email", "name") values (?, ?)`),
+	})
+}
+
+func TestSqliteGrammarInsertGetId(t *testing.T) {
+
+	g := NewSqliteGrammar()
+
+	runSqlCases(t, []sqlCase{
+		rawCase("insert get id", g.CompileInsertGetId(newBuilder(g).From("users"), upsertValues, []string{"email", "name"}, "id"),
+			`insert into "users"("email", "name") values (?, ?) returning "id"`),
+	})
+}
+
+func TestSqliteGrammarLocks(t *testing.T) {
+
+	g := NewSqliteGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g).From("users").Where("id", 1) }
+
+	runSqlCases(t, []sqlCase{
+		selectCase("lock is ignored", q().LockForUpdate(),
+			`select * from "users" where "id" = ?`, 1),
+	})
+
+	if g.SupportsLockModifiers() {
+		t.Error("sqlite must not claim lock modifier support")
+	}
+
+	if _, err := q().LockForUpdate().SkipLocked().Get(); err == nil {
+		t.Error("expected SkipLocked to fail on sqlite")
+	}
+
+	if _, err := q().LockForUpdate().NoWait().Get(); err == nil {
+		t.Error("expected NoWait to fail on sqlite")
+	}
+}
+
+func TestSqliteGrammarRawSql(t *testing.T) {
+
+	g := NewSqliteGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g).From("users") }
+
+	runSqlCases(t, []sqlCase{
+		rawCase("literals", q().Where("active", false).Where("hash", []byte{0xde, 0xad}).WhereNull("deleted_at").ToRawSql(),
+			`select * from "users" where "active" = 0 and "hash" = x'dead' and "deleted_at" is null`),
+	})
+}
+
+func TestSqliteGrammarTransactions(t *testing.T) {
+
+	g := NewSqliteGrammar()
+
+	runSqlCases(t, []sqlCase{
+		rawCase("set transaction", g.CompileSetTransaction(&sql.TxOptions{Isolation: sql.LevelSerializable}), ""),
+	})
+
+	if g.SupportsTransactionOptions() {
+		t.Error("sqlite must not claim transaction option support")
+	}
+
+	if g.SupportsReadOnlyTransactions() {
+		t.Error("sqlite must not claim read-only transaction support")
+	}
+}
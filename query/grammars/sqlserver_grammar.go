@@ -0,0 +1,349 @@
+package grammars
+
+import (
+	"database/contracts"
+	"database/query"
+	"database/query/types"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+type SqlServerGrammar struct {
+	*Grammar
+}
+
+func NewSqlServerGrammar() *SqlServerGrammar {
+
+	var sg = &SqlServerGrammar{
+		Grammar: NewGrammar(),
+	}
+
+	sg.Grammar.SetReturning(true)
+	sg.Grammar.SetInsertOrIgnore(false)
+	sg.Grammar.SetReadOnlyTransactions(false)
+	sg.Grammar.SetParametrizeSymbol("?")
+	sg.Grammar.SetParametrizeFormat("@p%d")
+	sg.Grammar.SetWrapSymbols("[", "]")
+	sg.Grammar.SetSelectComponents(sg.GetSqlServerSelectComponents())
+	sg.Grammar.SetWhereComponents(sg.GetSqlServerWhereComponents())
+	sg.Grammar.SetLiteralComponents(sg.GetSqlServerLiteralComponents())
+
+	return sg
+}
+
+func (g *SqlServerGrammar) GetSqlServerSelectComponents() map[int]interface{} {
+
+	return map[int]interface{}{
+		0: g.compileAggregate,
+		1: g.compileColumns,
+		2: g.compileFrom,
+		3: g.compileJoins,
+		4: g.compileWhere,
+		5: g.compileGroups,
+		6: g.compileHavings,
+		7: g.compileOrders,
+		8: g.compileOffset,
+		9: g.compileLock,
+	}
+}
+
+func (g *SqlServerGrammar) GetSqlServerWhereComponents() map[string]interface{} {
+
+	return map[string]interface{}{
+		"basic": g.whereBasic,
+		"date":  g.whereDate,
+	}
+}
+
+func (g *SqlServerGrammar) GetSqlServerLiteralComponents() map[string]interface{} {
+
+	return map[string]interface{}{
+		"string": g.escapeString,
+		"bool":   g.escapeBool,
+		"binary": g.escapeBinary,
+	}
+}
+
+func (g *SqlServerGrammar) CompileSelect(b contracts.QueryBuilder) string {
+
+	sql := g.Grammar.CompileSelect(b)
+
+	queryBuilder := b.(*query.Builder)
+
+	if len(queryBuilder.Unions) > 0 {
+
+		sql = "select * from (" + sql + ") as " + g.Wrap("temp_table") + " " + g.compileUnions(b, queryBuilder)
+	}
+
+	return sql
+}
+
+func (g *SqlServerGrammar) escapeBinary(value []byte) string {
+
+	return "0x" + hex.EncodeToString(value)
+}
+
+func (g *SqlServerGrammar) compileColumns(b contracts.QueryBuilder, queryBuilder *query.Builder) string {
+
+	sql := g.Grammar.compileColumns(b, queryBuilder)
+
+	if len(sql) > 0 && queryBuilder.RowLimit > 0 && queryBuilder.RowOffset <= 0 {
+		prefix := "select "
+		if queryBuilder.IsDistinct {
+			prefix = "select distinct "
+		}
+
+		sql = fmt.Sprintf("%vtop %v %v", prefix, queryBuilder.RowLimit, sql[len(prefix):])
+	}
+
+	return sql
+}
+
+func (g *SqlServerGrammar) compileFrom(b contracts.QueryBuilder, queryBuilder *query.Builder) string {
+
+	sql := g.Grammar.compileFrom(b, queryBuilder)
+
+	lock := queryBuilder.RowLock
+	if lock == nil {
+		return sql
+	}
+
+	if raw, ok := lock.(types.ExpressionType); ok {
+		return sql + " " + raw.ValueToString()
+	}
+
+	hints := []string{"rowlock", "holdlock"}
+	if lock.GetMode() == "update" {
+		hints = []string{"rowlock", "updlock", "holdlock"}
+	}
+
+	switch lock.GetModifier() {
+	case "skip locked":
+		hints = append(hints, "readpast")
+		break
+	case "nowait":
+		hints = append(hints, "nowait")
+		break
+	}
+
+	return sql + " with(" + strings.Join(hints, ",") + ")"
+}
+
+func (g *SqlServerGrammar) compileLock(b contracts.QueryBuilder, queryBuilder *query.Builder) string {
+
+	return ""
+}
+
+func (g *SqlServerGrammar) compileOrders(b contracts.QueryBuilder, queryBuilder *query.Builder) string {
+
+	if len(queryBuilder.Orders) <= 0 && queryBuilder.RowOffset > 0 {
+		return "order by (select 0)"
+	}
+
+	return g.Grammar.compileOrders(b, queryBuilder)
+}
+
+func (g *SqlServerGrammar) compileOffset(b contracts.QueryBuilder, queryBuilder *query.Builder) string {
+
+	if queryBuilder.RowOffset <= 0 {
+		return ""
+	}
+
+	sql := fmt.Sprintf("offset %v rows", queryBuilder.RowOffset)
+
+	if queryBuilder.RowLimit > 0 {
+		sql += fmt.Sprintf(" fetch next %v rows only", queryBuilder.RowLimit)
+	}
+
+	return sql
+}
+
+func (g *SqlServerGrammar) compileUnions(b contracts.QueryBuilder, queryBuilder *query.Builder) string {
+
+	sql := ""
+	for _, v := range queryBuilder.Unions {
+		sql += g.compileUnion(v)
+	}
+
+	if len(queryBuilder.UnionOrders) <= 0 && queryBuilder.UnionLimit <= 0 && queryBuilder.UnionOffset <= 0 {
+		return strings.TrimLeft(sql, " ")
+	}
+
+	orderTmp, limitTmp, offsetTmp := queryBuilder.Orders, queryBuilder.RowLimit, queryBuilder.RowOffset
+
+	queryBuilder.Orders = queryBuilder.UnionOrders
+	queryBuilder.RowLimit = queryBuilder.UnionLimit
+	queryBuilder.RowOffset = queryBuilder.UnionOffset
+
+	if len(queryBuilder.Orders) <= 0 {
+		sql += " order by (select 0)"
+	} else {
+		sql += " " + g.Grammar.compileOrders(b, queryBuilder)
+	}
+
+	sql += fmt.Sprintf(" offset %v rows", queryBuilder.RowOffset)
+
+	if queryBuilder.RowLimit > 0 {
+		sql += fmt.Sprintf(" fetch next %v rows only", queryBuilder.RowLimit)
+	}
+
+	queryBuilder.Orders, queryBuilder.RowLimit, queryBuilder.RowOffset = orderTmp, limitTmp, offsetTmp
+
+	return strings.TrimLeft(sql, " ")
+}
+
+func (g *SqlServerGrammar) compileUnion(union types.UnionType) string {
+
+	conjunction := " union "
+	if union.IsAll() {
+		conjunction = " union all "
+	}
+
+	return conjunction + "select * from (" + union.GetValue().ToSql() + ") as " + g.Wrap("temp_table")
+}
+
+func (g *SqlServerGrammar) whereDate(w types.WhereType) string {
+
+	where := w.(types.WhereDateType)
+
+	switch where.GetDateType() {
+	case "date", "time":
+		return fmt.Sprintf("cast(%v as %v) %v %v",
+			g.Wrap(w.GetColumn()),
+			where.GetDateType(),
+			w.GetOperator(),
+			g.parameterizeWhere(w, ", "),
+		)
+	}
+
+	return g.Grammar.whereDate(w)
+}
+
+func (g *SqlServerGrammar) CompileInsertGetId(
+	b contracts.QueryBuilder, values []map[string]interface{}, columns []string, sequence string,
+) string {
+
+	output := ") output inserted." + g.Wrap(sequence) + " values "
+
+	return strings.Replace(g.CompileInsert(b, values, columns), ") values ", output, 1)
+}
+
+func (g *SqlServerGrammar) CompileUpsert(
+	b contracts.QueryBuilder, values []map[string]interface{}, columns []string, uniqueBy []string, update []string,
+) string {
+
+	builder := b.(*query.Builder)
+	table := g.WrapTable(builder.Table)
+	alias := g.Wrap("upsert_alias")
+	mock := make([]interface{}, len(columns))
+
+	var params []string
+	for range values {
+		params = append(params, "("+g.parameterize(mock, ", ")+")")
+	}
+
+	var on []string
+	for _, col := range uniqueBy {
+		on = append(on, g.Wrap("upsert_alias."+col)+" = "+table+"."+g.Wrap(col))
+	}
+
+	var set []string
+	for _, col := range update {
+		set = append(set, g.Wrap(col)+" = "+g.Wrap("upsert_alias."+col))
+	}
+
+	var inserts []string
+	for _, col := range columns {
+		inserts = append(inserts, g.Wrap("upsert_alias."+col))
+	}
+
+	return "merge " + table + " using (values " + strings.Join(params, ", ") + ") " + alias +
+		" (" + g.columnize(columns) + ") on " + strings.Join(on, " and ") +
+		" when matched then update set " + strings.Join(set, ", ") +
+		" when not matched then insert (" + g.columnize(columns) + ") values (" + strings.Join(inserts, ", ") + ");"
+}
+
+func (g *SqlServerGrammar) CompileUpdate(b contracts.QueryBuilder, values map[string]interface{}) string {
+
+	builder := b.(*query.Builder)
+
+	if len(builder.Joins) <= 0 {
+		return g.Grammar.CompileUpdate(b, values)
+	}
+
+	table := g.WrapTable(builder.Table)
+
+	var columns []string
+	for _, col := range g.sortedColumns(values) {
+		columns = append(columns, g.Wrap(col)+" = "+g.parametrizeSymbol)
+	}
+
+	joins := " " + g.compileJoins(b, builder)
+	wheres := g.compileWhere(b, builder)
+
+	q := "update " + table + " set " + strings.Join(columns, ", ") + " from " + table + joins + " " + wheres
+
+	return strings.Trim(q, " ")
+}
+
+func (g *SqlServerGrammar) PrepareBindingsForUpdate(
+	b contracts.QueryBuilder, bindings map[string][]interface{}, values map[string]interface{},
+) []interface{} {
+
+	var res []interface{}
+	for _, col := range g.sortedColumns(values) {
+		res = append(res, values[col])
+	}
+
+	var queryBuilder = b.(*query.Builder)
+	exceptBindings := queryBuilder.GetBindingsForSql("select")
+
+	res = append(res, exceptBindings...)
+
+	return res
+}
+
+func (g *SqlServerGrammar) CompileDelete(b contracts.QueryBuilder) string {
+
+	builder := b.(*query.Builder)
+
+	if len(builder.Joins) <= 0 {
+		return g.Grammar.CompileDelete(b)
+	}
+
+	table := g.WrapTable(builder.Table)
+	joins := " " + g.compileJoins(b, builder)
+	wheres := g.compileWhere(b, builder)
+
+	return strings.Trim("delete "+table+" from "+table+joins+" "+wheres, " ")
+}
+
+func (g *SqlServerGrammar) CompileTruncate(b contracts.QueryBuilder) string {
+
+	builder := b.(*query.Builder)
+	table := g.WrapTable(builder.Table)
+
+	return "truncate table " + table
+}
+
+func (g *SqlServerGrammar) CompileSavepoint(name string) string {
+
+	return "SAVE TRANSACTION " + name
+}
+
+func (g *SqlServerGrammar) CompileSavepointRollback(name string) string {
+
+	return "ROLLBACK TRANSACTION " + name
+}
+
+func (g *SqlServerGrammar) CompileSetTransaction(opts *sql.TxOptions) string {
+
+	level := g.compileIsolationLevel(opts.Isolation)
+	if len(level) <= 0 {
+		return ""
+	}
+
+	return "set transaction isolation level " + level
+}
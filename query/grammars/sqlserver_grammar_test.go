@@ -0,0 +1,146 @@
+package grammars
+
+import (
+	"database/contracts"
+	"database/sql"
+	"testing"
+)
+
+func TestSqlServerGrammarSelect(t *testing.T) {
+
+	g := NewSqlServerGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g) }
+
+	runSqlCases(t, []sqlCase{
+		selectCase("select",
+			q().From("users").Select("id", "name").Where("id", ">", 1).OrderBy("name", "asc"),
+			"select [id], [name] from [users] where [id] > ? order by [name] asc", 1),
+		selectCase("top", q().From("users").Limit(10),
+			"select top 10 * from [users]"),
+		selectCase("offset fetch", q().From("users").Limit(10).Offset(5),
+			"select * from [users] order by (select 0) offset 5 rows fetch next 10 rows only"),
+		selectCase("union", q().From("users").Where("a", 1).UnionAll(q().From("admins")),
+			"select * from (select * from [users] where [a] = ?) as [temp_table] union all select * from (select * from [admins]) as [temp_table]"),
+		rawCase("parameters", g.SubstituteParameters("select * from [users] where [a] = ? and [b] = ?"),
+			"select * from [users] where [a] = @p1 and [b] = @p2"),
+	})
+}
+
+func TestSqlServerGrammarUpdateAndDeleteWithJoins(t *testing.T) {
+
+	g := NewSqlServerGrammar()
+	q := func() contracts.QueryBuilder {
+		return newBuilder(g).From("users").Join("posts", "users.id", "=", "posts.user_id").Where("posts.id", 7)
+	}
+
+	update := q()
+	updateValues := map[string]interface{}{"name": "n", "age": 3}
+
+	runSqlCases(t, []sqlCase{
+		{
+			name:     "update with joins",
+			sql:      g.CompileUpdate(update, updateValues),
+			expected: "update [users] set [age] = ?, [name] = ? from [users] inner join [posts] on [users].[id] = [posts].[user_id] where [posts].[id] = ?",
+			bindings: []interface{}{3, "n", 7},
+			actual:   g.PrepareBindingsForUpdate(update, map[string][]interface{}{}, updateValues),
+		},
+		rawCase("delete with joins", g.CompileDelete(q()),
+			"delete [users] from [users] inner join [posts] on [users].[id] = [posts].[user_id] where [posts].[id] = ?"),
+	})
+}
+
+func TestSqlServerGrammarDistinctAndUnions(t *testing.T) {
+
+	g := NewSqlServerGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g).From("users") }
+
+	runSqlCases(t, []sqlCase{
+		selectCase("distinct top", q().Distinct().Select("name").Limit(5),
+			"select distinct top 5 [name] from [users]"),
+		selectCase("distinct count", withAggregate(q().Distinct(), "count", "name"),
+			"select count(distinct [name]) as aggregate from [users]"),
+		selectCase("union", q().Where("a", 1).Union(newBuilder(g).From("admins").Where("b", 2)),
+			"select * from (select * from [users] where [a] = ?) as [temp_table] union select * from (select * from [admins] where [b] = ?) as [temp_table]", 1, 2),
+	})
+}
+
+func TestSqlServerGrammarUpsertAndInsertOrIgnore(t *testing.T) {
+
+	g := NewSqlServerGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g).From("users") }
+
+	columns := []string{"email", "name"}
+
+	runSqlCases(t, []sqlCase{
+		rawCase("upsert", g.CompileUpsert(q(), upsertValues, columns, []string{"email"}, []string{"name"}),
+			"merge [users] using (values (?, ?)) [upsert_alias] ([email], [name]) on [upsert_alias].[email] = [users].[email] "+
+				"when matched then update set [name] = [upsert_alias].[name] "+
+				"when not matched then insert ([email], [name]) values ([upsert_alias].[email], [upsert_alias].[name]);"),
+	})
+
+	if g.SupportsInsertOrIgnore() {
+		t.Error("sql server must not claim insert-or-ignore support")
+	}
+
+	if _, err := q().InsertOrIgnore(upsertValues...); err == nil {
+		t.Error("expected InsertOrIgnore to fail on sql server")
+	}
+}
+
+func TestSqlServerGrammarInsertGetId(t *testing.T) {
+
+	g := NewSqlServerGrammar()
+
+	runSqlCases(t, []sqlCase{
+		rawCase("insert get id", g.CompileInsertGetId(newBuilder(g).From("users"), upsertValues, []string{"email", "name"}, "id"),
+			"insert into [users]([email], [name]) output inserted.[id] values (?, ?)"),
+	})
+}
+
+func TestSqlServerGrammarLocks(t *testing.T) {
+
+	g := NewSqlServerGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g).From("users").Where("id", 1) }
+
+	runSqlCases(t, []sqlCase{
+		selectCase("lock for update", q().LockForUpdate(),
+			"select * from [users] with(rowlock,updlock,holdlock) where [id] = ?", 1),
+		selectCase("shared lock", q().SharedLock(),
+			"select * from [users] with(rowlock,holdlock) where [id] = ?", 1),
+		selectCase("skip locked", q().LockForUpdate().SkipLocked(),
+			"select * from [users] with(rowlock,updlock,holdlock,readpast) where [id] = ?", 1),
+		selectCase("nowait", q().LockForUpdate().NoWait(),
+			"select * from [users] with(rowlock,updlock,holdlock,nowait) where [id] = ?", 1),
+	})
+}
+
+func TestSqlServerGrammarRawSql(t *testing.T) {
+
+	g := NewSqlServerGrammar()
+	q := func() contracts.QueryBuilder { return newBuilder(g).From("users") }
+
+	runSqlCases(t, []sqlCase{
+		rawCase("literals", q().Where("active", true).Where("hash", []byte{0xde, 0xad}).ToRawSql(),
+			"select * from [users] where [active] = 1 and [hash] = 0xdead"),
+		rawCase("top", q().Where("name", "o'k").Limit(1).ToRawSql(),
+			"select top 1 * from [users] where [name] = 'o''k'"),
+	})
+}
+
+func TestSqlServerGrammarTransactions(t *testing.T) {
+
+	g := NewSqlServerGrammar()
+
+	runSqlCases(t, []sqlCase{
+		rawCase("set transaction", g.CompileSetTransaction(&sql.TxOptions{Isolation: sql.LevelSnapshot}),
+			"set transaction isolation level snapshot"),
+	})
+
+	if !g.SupportsTransactionOptions() {
+		t.Error("sql server must pass the isolation level through sql.TxOptions")
+	}
+
+	if g.SupportsReadOnlyTransactions() {
+		t.Error("sql server must not claim read-only transaction support")
+	}
+}
@@ -0,0 +1,201 @@
+package query
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+func scanRows(rows *sql.Rows, dest interface{}, single bool) error {
+
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return errors.New("Scan destination must be a non-nil pointer")
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	target := value.Elem()
+
+	if single {
+		if !rows.Next() {
+			if err := rows.Err(); err != nil {
+				return err
+			}
+
+			return sql.ErrNoRows
+		}
+
+		if err := scanRow(rows, columns, target); err != nil {
+			return err
+		}
+
+		return rows.Err()
+	}
+
+	if target.Kind() != reflect.Slice {
+		return errors.New("Scan destination must be a pointer to slice")
+	}
+
+	itemType := target.Type().Elem()
+	items := reflect.MakeSlice(target.Type(), 0, 0)
+
+	for rows.Next() {
+		item := reflect.New(itemType).Elem()
+
+		if err := scanRow(rows, columns, item); err != nil {
+			return err
+		}
+
+		items = reflect.Append(items, item)
+	}
+
+	target.Set(items)
+
+	return rows.Err()
+}
+
+func scanRow(rows *sql.Rows, columns []string, target reflect.Value) error {
+
+	if target.Kind() == reflect.Ptr && isRowStruct(target.Type().Elem()) {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+
+		return scanRow(rows, columns, target.Elem())
+	}
+
+	switch {
+	case target.Kind() == reflect.Map:
+		return scanMap(rows, columns, target)
+	case isRowStruct(target.Type()):
+		return scanStruct(rows, columns, target)
+	}
+
+	if len(columns) != 1 {
+		return errors.New("Scan into a single value expects exactly one column")
+	}
+
+	return rows.Scan(target.Addr().Interface())
+}
+
+func scanMap(rows *sql.Rows, columns []string, target reflect.Value) error {
+
+	if target.Type() != reflect.TypeOf(map[string]interface{}{}) {
+		return errors.New("Scan destination map must be map[string]interface{}")
+	}
+
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	if err := rows.Scan(pointers...); err != nil {
+		return err
+	}
+
+	res := make(map[string]interface{}, len(columns))
+	for i, col := range columns {
+		res[col] = normalizeValue(values[i])
+	}
+
+	target.Set(reflect.ValueOf(res))
+
+	return nil
+}
+
+func scanStruct(rows *sql.Rows, columns []string, target reflect.Value) error {
+
+	fields := make(map[string]reflect.Value)
+	collectFields(target, fields)
+
+	pointers := make([]interface{}, len(columns))
+	for i, col := range columns {
+		if field, ok := fields[strings.ToLower(col)]; ok {
+			pointers[i] = field.Addr().Interface()
+		} else {
+			pointers[i] = new(interface{})
+		}
+	}
+
+	return rows.Scan(pointers...)
+}
+
+func collectFields(target reflect.Value, fields map[string]reflect.Value) {
+
+	targetType := target.Type()
+
+	var embedded []reflect.Value
+
+	for i := 0; i < targetType.NumField(); i++ {
+		field := targetType.Field(i)
+		tag := field.Tag.Get("db")
+
+		if tag == "-" {
+			continue
+		}
+
+		if field.Anonymous && len(tag) <= 0 {
+			value := target.Field(i)
+
+			if value.Kind() == reflect.Ptr && isRowStruct(value.Type().Elem()) && value.CanSet() {
+				if value.IsNil() {
+					value.Set(reflect.New(value.Type().Elem()))
+				}
+				value = value.Elem()
+			}
+
+			if isRowStruct(value.Type()) {
+				embedded = append(embedded, value)
+				continue
+			}
+		}
+
+		if len(field.PkgPath) > 0 {
+			continue
+		}
+
+		name := strings.ToLower(field.Name)
+		if len(tag) > 0 {
+			name = strings.ToLower(strings.Split(tag, ",")[0])
+		}
+
+		fields[name] = target.Field(i)
+	}
+
+	for _, value := range embedded {
+		nested := make(map[string]reflect.Value)
+		collectFields(value, nested)
+
+		for name, field := range nested {
+			if _, exists := fields[name]; !exists {
+				fields[name] = field
+			}
+		}
+	}
+}
+
+func isRowStruct(t reflect.Type) bool {
+
+	return t.Kind() == reflect.Struct && t != timeType && !reflect.PtrTo(t).Implements(scannerType)
+}
+
+func normalizeValue(value interface{}) interface{} {
+
+	if v, ok := value.([]byte); ok {
+		return string(v)
+	}
+
+	return value
+}
@@ -0,0 +1,164 @@
+package query_test
+
+import (
+	"database/internal/fakedb"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+type timestamps struct {
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type user struct {
+	timestamps
+	Id       int64
+	Name     string         `db:"full_name"`
+	Email    sql.NullString `db:"email"`
+	Age      *int64
+	Password string `db:"-"`
+}
+
+func TestScanIntoStructs(t *testing.T) {
+
+	server, connection := newFakeConnection()
+
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	server.QueueRows([]string{"id", "full_name", "email", "age", "created_at", "password", "extra"},
+		[]driver.Value{int64(1), "Jane", "jane@example.com", int64(30), created, "secret", "ignored"},
+		[]driver.Value{int64(2), []byte("John"), nil, nil, created, "secret", "ignored"},
+	)
+
+	var users []user
+	if err := connection.Table("users").Where("id", ">", 0).Scan(&users); err != nil {
+		t.Fatal(err)
+	}
+
+	assertCalls(t, server, fakedb.Call{Query: "select * from \"users\" where \"id\" > ?", Args: args(int64(0))})
+
+	if len(users) != 2 {
+		t.Fatalf("expected 2 users, got %d", len(users))
+	}
+
+	first, second := users[0], users[1]
+
+	if first.Id != 1 || first.Name != "Jane" || !first.Email.Valid || first.Email.String != "jane@example.com" {
+		t.Errorf("unexpected first user %+v", first)
+	}
+
+	if first.Age == nil || *first.Age != 30 || !first.CreatedAt.Equal(created) || len(first.Password) > 0 {
+		t.Errorf("unexpected first user %+v", first)
+	}
+
+	if second.Name != "John" || second.Email.Valid || second.Age != nil {
+		t.Errorf("unexpected second user %+v", second)
+	}
+}
+
+func TestScanIntoMapsAndPointers(t *testing.T) {
+
+	server, connection := newFakeConnection()
+
+	server.QueueRows([]string{"id", "name"},
+		[]driver.Value{int64(1), []byte("Jane")},
+	)
+	server.QueueRows([]string{"id", "full_name"},
+		[]driver.Value{int64(2), "John"},
+	)
+
+	var rows []map[string]interface{}
+	if err := connection.Table("users").Scan(&rows); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 1 || rows[0]["id"] != int64(1) || rows[0]["name"] != "Jane" {
+		t.Errorf("unexpected rows %v", rows)
+	}
+
+	var users []*user
+	if err := connection.Table("users").Scan(&users); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(users) != 1 || users[0].Id != 2 || users[0].Name != "John" {
+		t.Errorf("unexpected users %v", users)
+	}
+}
+
+func TestFirst(t *testing.T) {
+
+	server, connection := newFakeConnection()
+
+	server.QueueRows([]string{"id", "full_name"}, []driver.Value{int64(1), "Jane"})
+	server.QueueRows([]string{"id", "full_name"})
+
+	var found user
+	if err := connection.Table("users").Where("id", 1).First(&found); err != nil {
+		t.Fatal(err)
+	}
+
+	if found.Id != 1 || found.Name != "Jane" {
+		t.Errorf("unexpected user %+v", found)
+	}
+
+	var missing user
+	if err := connection.Table("users").Where("id", 2).First(&missing); err != sql.ErrNoRows {
+		t.Errorf("expected sql.ErrNoRows, got %v", err)
+	}
+
+	assertCalls(t, server,
+		fakedb.Call{Query: "select * from \"users\" where \"id\" = ? limit 1", Args: args(int64(1))},
+		fakedb.Call{Query: "select * from \"users\" where \"id\" = ? limit 1", Args: args(int64(2))},
+	)
+}
+
+func TestPluckAndValue(t *testing.T) {
+
+	server, connection := newFakeConnection()
+
+	server.QueueRows([]string{"name"}, []driver.Value{"Jane"}, []driver.Value{"John"})
+	server.QueueRows([]string{"name"}, []driver.Value{[]byte("Jane")})
+
+	var names []string
+	if err := connection.Table("users").Pluck("name", &names); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(names) != 2 || names[0] != "Jane" || names[1] != "John" {
+		t.Errorf("unexpected names %v", names)
+	}
+
+	value, err := connection.Table("users").Where("id", 1).Value("name")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != "Jane" {
+		t.Errorf("expected Jane, got %v", value)
+	}
+
+	assertCalls(t, server,
+		fakedb.Call{Query: "select \"name\" from \"users\""},
+		fakedb.Call{Query: "select \"name\" from \"users\" where \"id\" = ? limit 1", Args: args(int64(1))},
+	)
+}
+
+func TestScanRejectsInvalidDestinations(t *testing.T) {
+
+	server, connection := newFakeConnection()
+
+	server.QueueRows([]string{"id", "name"}, []driver.Value{int64(1), "Jane"})
+	server.QueueRows([]string{"id", "name"}, []driver.Value{int64(1), "Jane"})
+
+	var users []user
+	if err := connection.Table("users").Scan(users); err == nil {
+		t.Error("expected an error for a non-pointer destination")
+	}
+
+	var ids []int64
+	if err := connection.Table("users").Scan(&ids); err == nil {
+		t.Error("expected an error when scanning two columns into a scalar")
+	}
+}
@@ -0,0 +1,44 @@
+package types
+
+type LockType interface {
+	GetMode() string
+	GetModifier() string
+}
+
+type Lock struct {
+	mode     string
+	modifier string
+}
+
+func (l *Lock) GetMode() string {
+	return l.mode
+}
+
+func (l *Lock) GetModifier() string {
+	return l.modifier
+}
+
+func NewLock(mode string, modifier string) *Lock {
+
+	return &Lock{
+		mode:     mode,
+		modifier: modifier,
+	}
+}
+
+type LockRaw struct {
+	*Lock
+	sql string
+}
+
+func (l *LockRaw) ValueToString() string {
+	return l.sql
+}
+
+func NewLockRaw(sql string) *LockRaw {
+
+	return &LockRaw{
+		sql:  sql,
+		Lock: NewLock("raw", ""),
+	}
+}
@@ -151,6 +151,22 @@ func NewWhereBool(col string, operator string, value bool, logic string) *WhereB
 	}
 }
 
+type WhereValue struct {
+	*Where
+	value interface{}
+}
+
+func (w *WhereValue) ValueToArray() []interface{} {
+	return []interface{}{w.value}
+}
+
+func NewWhereValue(col string, operator string, value interface{}, logic string) *WhereValue {
+	return &WhereValue{
+		value: value,
+		Where: newWhere(col, operator, logic),
+	}
+}
+
 type WhereNull struct {
 	*Where
 }
@@ -0,0 +1,220 @@
+package schema
+
+import (
+	"database/contracts"
+	"strings"
+)
+
+type Command struct {
+	Name    string
+	Index   string
+	Columns []string
+	From    string
+	To      string
+	Foreign *ForeignKeyDefinition
+}
+
+type Blueprint struct {
+	table    string
+	columns  []*ColumnDefinition
+	commands []*Command
+}
+
+func NewBlueprint(table string) *Blueprint {
+
+	return &Blueprint{
+		table: table,
+	}
+}
+
+func (b *Blueprint) GetTable() string {
+
+	return b.table
+}
+
+func (b *Blueprint) GetColumns() []*ColumnDefinition {
+
+	return b.columns
+}
+
+func (b *Blueprint) GetCommands() []*Command {
+
+	if len(b.columns) > 0 && !b.creating() {
+		return append([]*Command{{Name: "add"}}, b.commands...)
+	}
+
+	return b.commands
+}
+
+func (b *Blueprint) Create() {
+
+	b.addCommand(&Command{Name: "create"})
+}
+
+func (b *Blueprint) Drop() {
+
+	b.addCommand(&Command{Name: "drop"})
+}
+
+func (b *Blueprint) DropIfExists() {
+
+	b.addCommand(&Command{Name: "dropIfExists"})
+}
+
+func (b *Blueprint) Increments(column string) contracts.ColumnDefinition {
+
+	c := b.addColumn("integer", column)
+	c.unsigned = true
+	c.autoIncrement = true
+
+	return c
+}
+
+func (b *Blueprint) BigIncrements(column string) contracts.ColumnDefinition {
+
+	c := b.addColumn("bigInteger", column)
+	c.unsigned = true
+	c.autoIncrement = true
+
+	return c
+}
+
+func (b *Blueprint) String(column string, length ...int) contracts.ColumnDefinition {
+
+	c := b.addColumn("string", column)
+	c.length = 255
+
+	if len(length) > 0 {
+		c.length = length[0]
+	}
+
+	return c
+}
+
+func (b *Blueprint) Text(column string) contracts.ColumnDefinition {
+
+	return b.addColumn("text", column)
+}
+
+func (b *Blueprint) Integer(column string) contracts.ColumnDefinition {
+
+	return b.addColumn("integer", column)
+}
+
+func (b *Blueprint) BigInteger(column string) contracts.ColumnDefinition {
+
+	return b.addColumn("bigInteger", column)
+}
+
+func (b *Blueprint) Boolean(column string) contracts.ColumnDefinition {
+
+	return b.addColumn("boolean", column)
+}
+
+func (b *Blueprint) Decimal(column string, total int, places int) contracts.ColumnDefinition {
+
+	c := b.addColumn("decimal", column)
+	c.total = total
+	c.places = places
+
+	return c
+}
+
+func (b *Blueprint) Json(column string) contracts.ColumnDefinition {
+
+	return b.addColumn("json", column)
+}
+
+func (b *Blueprint) Timestamp(column string) contracts.ColumnDefinition {
+
+	return b.addColumn("timestamp", column)
+}
+
+func (b *Blueprint) Timestamps() {
+
+	b.Timestamp("created_at").Nullable()
+	b.Timestamp("updated_at").Nullable()
+}
+
+func (b *Blueprint) Primary(columns ...string) {
+
+	b.indexCommand("primary", columns)
+}
+
+func (b *Blueprint) Index(columns ...string) {
+
+	b.indexCommand("index", columns)
+}
+
+func (b *Blueprint) Unique(columns ...string) {
+
+	b.indexCommand("unique", columns)
+}
+
+func (b *Blueprint) Foreign(columns ...string) contracts.ForeignKeyDefinition {
+
+	command := b.indexCommand("foreign", columns)
+	command.Foreign = NewForeignKeyDefinition()
+
+	return command.Foreign
+}
+
+func (b *Blueprint) DropColumn(columns ...string) {
+
+	b.addCommand(&Command{Name: "dropColumn", Columns: columns})
+}
+
+func (b *Blueprint) RenameColumn(from string, to string) {
+
+	b.addCommand(&Command{Name: "renameColumn", From: from, To: to})
+}
+
+func (b *Blueprint) DropIndex(name string) {
+
+	b.addCommand(&Command{Name: "dropIndex", Index: name})
+}
+
+func (b *Blueprint) DropUnique(name string) {
+
+	b.addCommand(&Command{Name: "dropUnique", Index: name})
+}
+
+func (b *Blueprint) DropForeign(name string) {
+
+	b.addCommand(&Command{Name: "dropForeign", Index: name})
+}
+
+func (b *Blueprint) creating() bool {
+
+	for _, command := range b.commands {
+		if command.Name == "create" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b *Blueprint) addColumn(columnType string, name string) *ColumnDefinition {
+
+	column := NewColumnDefinition(columnType, name)
+	b.columns = append(b.columns, column)
+
+	return column
+}
+
+func (b *Blueprint) indexCommand(name string, columns []string) *Command {
+
+	index := strings.ToLower(b.table + "_" + strings.Join(columns, "_") + "_" + name)
+	index = strings.NewReplacer("-", "_", ".", "_").Replace(index)
+
+	command := &Command{Name: name, Index: index, Columns: columns}
+	b.addCommand(command)
+
+	return command
+}
+
+func (b *Blueprint) addCommand(command *Command) {
+
+	b.commands = append(b.commands, command)
+}
@@ -0,0 +1,106 @@
+package schema
+
+import (
+	"database/contracts"
+	"errors"
+)
+
+type Builder struct {
+	connection contracts.Connection
+	grammar    contracts.SchemaGrammar
+}
+
+func NewBuilder(connection contracts.Connection, grammar contracts.SchemaGrammar) contracts.SchemaBuilder {
+
+	return &Builder{
+		connection: connection,
+		grammar:    grammar,
+	}
+}
+
+func (b *Builder) Create(table string, callback func(table contracts.Blueprint)) error {
+
+	blueprint := NewBlueprint(table)
+	blueprint.Create()
+
+	callback(blueprint)
+
+	return b.build(blueprint)
+}
+
+func (b *Builder) Table(table string, callback func(table contracts.Blueprint)) error {
+
+	blueprint := NewBlueprint(table)
+
+	callback(blueprint)
+
+	return b.build(blueprint)
+}
+
+func (b *Builder) Drop(table string) error {
+
+	blueprint := NewBlueprint(table)
+	blueprint.Drop()
+
+	return b.build(blueprint)
+}
+
+func (b *Builder) DropIfExists(table string) error {
+
+	blueprint := NewBlueprint(table)
+	blueprint.DropIfExists()
+
+	return b.build(blueprint)
+}
+
+func (b *Builder) HasTable(table string) (bool, error) {
+
+	if b.grammar == nil {
+		return false, errors.New("Schema grammar is not supported by connection")
+	}
+
+	return b.exists(b.grammar.CompileTableExists(), []interface{}{table})
+}
+
+func (b *Builder) HasColumn(table string, column string) (bool, error) {
+
+	if b.grammar == nil {
+		return false, errors.New("Schema grammar is not supported by connection")
+	}
+
+	return b.exists(b.grammar.CompileColumnExists(), []interface{}{table, column})
+}
+
+func (b *Builder) exists(query string, bindings []interface{}) (bool, error) {
+
+	rows, err := b.connection.SelectFromWriteConnection(query, bindings)
+	if err != nil {
+		return false, err
+	}
+
+	defer rows.Close()
+
+	var count int64
+	if rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return false, err
+		}
+	}
+
+	return count > 0, rows.Err()
+}
+
+func (b *Builder) build(blueprint *Blueprint) error {
+
+	if b.grammar == nil {
+		return errors.New("Schema grammar is not supported by connection")
+	}
+
+	for _, statement := range b.grammar.CompileBlueprint(blueprint) {
+		if _, err := b.connection.Statement(statement, []interface{}{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package schema_test
+
+import (
+	"database/connections"
+	"database/internal/fakedb"
+	"database/kernel/config"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+func TestExistenceChecksReadFromTheWriteConnection(t *testing.T) {
+
+	write, read := fakedb.New(), fakedb.New()
+
+	connection := connections.NewMysqlConnection(write.Open(), &config.DatabaseDriver{})
+	connection.SetReadPDOs([]*sql.DB{read.Open()})
+
+	write.QueueRows([]string{"count"}, []driver.Value{int64(1)})
+	write.QueueRows([]string{"count"}, []driver.Value{int64(0)})
+
+	hasTable, err := connection.Schema().HasTable("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hasColumn, err := connection.Schema().HasColumn("users", "email")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !hasTable || hasColumn {
+		t.Errorf("unexpected results table=%v column=%v", hasTable, hasColumn)
+	}
+
+	if len(read.Queries()) > 0 || len(write.Queries()) != 2 {
+		t.Errorf("unexpected routing\nread: %q\nwrite: %q", read.Queries(), write.Queries())
+	}
+}
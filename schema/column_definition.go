@@ -0,0 +1,126 @@
+package schema
+
+import "database/contracts"
+
+type ColumnDefinition struct {
+	name       string
+	columnType string
+
+	length int
+	total  int
+	places int
+
+	nullable      bool
+	unsigned      bool
+	autoIncrement bool
+
+	hasDefault   bool
+	defaultValue interface{}
+
+	comment string
+	after   string
+}
+
+func NewColumnDefinition(columnType string, name string) *ColumnDefinition {
+
+	return &ColumnDefinition{
+		name:       name,
+		columnType: columnType,
+	}
+}
+
+func (c *ColumnDefinition) Nullable() contracts.ColumnDefinition {
+
+	c.nullable = true
+
+	return c
+}
+
+func (c *ColumnDefinition) Default(value interface{}) contracts.ColumnDefinition {
+
+	c.hasDefault = true
+	c.defaultValue = value
+
+	return c
+}
+
+func (c *ColumnDefinition) Unsigned() contracts.ColumnDefinition {
+
+	c.unsigned = true
+
+	return c
+}
+
+func (c *ColumnDefinition) Comment(comment string) contracts.ColumnDefinition {
+
+	c.comment = comment
+
+	return c
+}
+
+func (c *ColumnDefinition) After(column string) contracts.ColumnDefinition {
+
+	c.after = column
+
+	return c
+}
+
+func (c *ColumnDefinition) GetName() string {
+
+	return c.name
+}
+
+func (c *ColumnDefinition) GetType() string {
+
+	return c.columnType
+}
+
+func (c *ColumnDefinition) GetLength() int {
+
+	return c.length
+}
+
+func (c *ColumnDefinition) GetTotal() int {
+
+	return c.total
+}
+
+func (c *ColumnDefinition) GetPlaces() int {
+
+	return c.places
+}
+
+func (c *ColumnDefinition) IsNullable() bool {
+
+	return c.nullable
+}
+
+func (c *ColumnDefinition) IsUnsigned() bool {
+
+	return c.unsigned
+}
+
+func (c *ColumnDefinition) IsAutoIncrement() bool {
+
+	return c.autoIncrement
+}
+
+func (c *ColumnDefinition) HasDefault() bool {
+
+	return c.hasDefault
+}
+
+func (c *ColumnDefinition) GetDefault() interface{} {
+
+	return c.defaultValue
+}
+
+func (c *ColumnDefinition) GetComment() string {
+
+	return c.comment
+}
+
+func (c *ColumnDefinition) GetAfter() string {
+
+	return c.after
+}
@@ -0,0 +1,63 @@
+package schema
+
+import "database/contracts"
+
+type ForeignKeyDefinition struct {
+	references []string
+	on         string
+	onDelete   string
+	onUpdate   string
+}
+
+func NewForeignKeyDefinition() *ForeignKeyDefinition {
+
+	return &ForeignKeyDefinition{}
+}
+
+func (f *ForeignKeyDefinition) References(columns ...string) contracts.ForeignKeyDefinition {
+
+	f.references = columns
+
+	return f
+}
+
+func (f *ForeignKeyDefinition) On(table string) contracts.ForeignKeyDefinition {
+
+	f.on = table
+
+	return f
+}
+
+func (f *ForeignKeyDefinition) OnDelete(action string) contracts.ForeignKeyDefinition {
+
+	f.onDelete = action
+
+	return f
+}
+
+func (f *ForeignKeyDefinition) OnUpdate(action string) contracts.ForeignKeyDefinition {
+
+	f.onUpdate = action
+
+	return f
+}
+
+func (f *ForeignKeyDefinition) GetReferences() []string {
+
+	return f.references
+}
+
+func (f *ForeignKeyDefinition) GetOn() string {
+
+	return f.on
+}
+
+func (f *ForeignKeyDefinition) GetOnDelete() string {
+
+	return f.onDelete
+}
+
+func (f *ForeignKeyDefinition) GetOnUpdate() string {
+
+	return f.onUpdate
+}
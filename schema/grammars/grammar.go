@@ -0,0 +1,192 @@
+package grammars
+
+import (
+	"database/contracts"
+	"database/query/types"
+	"database/schema"
+	"fmt"
+	"strings"
+)
+
+type Grammar struct {
+	transactions       bool
+	wrapLeft           string
+	wrapRight          string
+	commandComponents  map[string]interface{}
+	typeComponents     map[string]interface{}
+	modifierComponents map[int]interface{}
+}
+
+func NewGrammar() *Grammar {
+
+	return &Grammar{
+		wrapLeft:           "`",
+		wrapRight:          "`",
+		commandComponents:  map[string]interface{}{},
+		typeComponents:     map[string]interface{}{},
+		modifierComponents: map[int]interface{}{},
+	}
+}
+
+func (g *Grammar) SetCommandComponents(m map[string]interface{}) {
+
+	g.commandComponents = m
+}
+
+func (g *Grammar) SetTypeComponents(m map[string]interface{}) {
+
+	g.typeComponents = m
+}
+
+func (g *Grammar) SetModifierComponents(m map[int]interface{}) {
+
+	g.modifierComponents = m
+}
+
+func (g *Grammar) SetSchemaTransactions(transactions bool) {
+
+	g.transactions = transactions
+}
+
+func (g *Grammar) SupportsSchemaTransactions() bool {
+
+	return g.transactions
+}
+
+func (g *Grammar) SetWrapSymbols(left string, right string) {
+
+	g.wrapLeft = left
+	g.wrapRight = right
+}
+
+func (g *Grammar) CompileBlueprint(b contracts.Blueprint) []string {
+
+	blueprint := b.(*schema.Blueprint)
+
+	res := make([]string, 0)
+	for _, command := range blueprint.GetCommands() {
+		f, ok := g.commandComponents[command.Name]
+		if !ok {
+			panic("Unsupported schema command " + command.Name)
+		}
+
+		sql := f.(func(*schema.Blueprint, *schema.Command) string)(blueprint, command)
+		if len(sql) > 0 {
+			res = append(res, sql)
+		}
+	}
+
+	return res
+}
+
+func (g *Grammar) getColumns(blueprint *schema.Blueprint) []string {
+
+	res := make([]string, 0)
+	for _, column := range blueprint.GetColumns() {
+		res = append(res, g.getColumn(blueprint, column))
+	}
+
+	return res
+}
+
+func (g *Grammar) getColumn(blueprint *schema.Blueprint, column *schema.ColumnDefinition) string {
+
+	f, ok := g.typeComponents[column.GetType()]
+	if !ok {
+		panic("Unsupported column type " + column.GetType())
+	}
+
+	sql := g.Wrap(column.GetName()) + " " + f.(func(*schema.ColumnDefinition) string)(column)
+
+	var l = len(g.modifierComponents)
+	for i := 0; i < l; i++ {
+		sql += g.modifierComponents[i].(func(*schema.Blueprint, *schema.ColumnDefinition) string)(blueprint, column)
+	}
+
+	return sql
+}
+
+func (g *Grammar) compileDrop(blueprint *schema.Blueprint, command *schema.Command) string {
+
+	return "drop table " + g.Wrap(blueprint.GetTable())
+}
+
+func (g *Grammar) compileDropIfExists(blueprint *schema.Blueprint, command *schema.Command) string {
+
+	return "drop table if exists " + g.Wrap(blueprint.GetTable())
+}
+
+func (g *Grammar) compileRenameColumn(blueprint *schema.Blueprint, command *schema.Command) string {
+
+	return fmt.Sprintf("alter table %v rename column %v to %v",
+		g.Wrap(blueprint.GetTable()),
+		g.Wrap(command.From),
+		g.Wrap(command.To),
+	)
+}
+
+func (g *Grammar) compileForeign(blueprint *schema.Blueprint, command *schema.Command) string {
+
+	foreign := command.Foreign
+
+	sql := fmt.Sprintf("alter table %v add constraint %v foreign key (%v) references %v (%v)",
+		g.Wrap(blueprint.GetTable()),
+		g.Wrap(command.Index),
+		g.columnize(command.Columns),
+		g.Wrap(foreign.GetOn()),
+		g.columnize(foreign.GetReferences()),
+	)
+
+	if len(foreign.GetOnDelete()) > 0 {
+		sql += " on delete " + foreign.GetOnDelete()
+	}
+
+	if len(foreign.GetOnUpdate()) > 0 {
+		sql += " on update " + foreign.GetOnUpdate()
+	}
+
+	return sql
+}
+
+func (g *Grammar) getDefaultValue(value interface{}) string {
+
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case types.ExpressionType:
+		return v.ValueToString()
+	case bool:
+		if v {
+			return "'1'"
+		}
+		return "'0'"
+	case string:
+		return "'" + strings.Replace(v, "'", "''", -1) + "'"
+	default:
+		return fmt.Sprintf("'%v'", v)
+	}
+}
+
+func (g *Grammar) columnize(columns []string) string {
+
+	res := make([]string, 0, len(columns))
+	for _, v := range columns {
+		res = append(res, g.Wrap(v))
+	}
+
+	return strings.Join(res, ", ")
+}
+
+func (g *Grammar) Wrap(v string) string {
+
+	if strings.Index(v, ".") > -1 {
+		var res []string
+		for _, v := range strings.Split(v, ".") {
+			res = append(res, g.Wrap(v))
+		}
+
+		return strings.Join(res, ".")
+	}
+
+	return g.wrapLeft + v + g.wrapRight
+}
@@ -0,0 +1,243 @@
+package grammars
+
+import (
+	"database/schema"
+	"fmt"
+	"strings"
+)
+
+type MysqlGrammar struct {
+	*Grammar
+}
+
+func NewMysqlGrammar() *MysqlGrammar {
+
+	var mg = &MysqlGrammar{
+		Grammar: NewGrammar(),
+	}
+
+	mg.Grammar.SetSchemaTransactions(false)
+	mg.Grammar.SetWrapSymbols("`", "`")
+	mg.Grammar.SetCommandComponents(mg.GetMysqlCommandComponents())
+	mg.Grammar.SetTypeComponents(mg.GetMysqlTypeComponents())
+	mg.Grammar.SetModifierComponents(mg.GetMysqlModifierComponents())
+
+	return mg
+}
+
+func (g *MysqlGrammar) GetMysqlCommandComponents() map[string]interface{} {
+
+	return map[string]interface{}{
+		"create":       g.compileCreate,
+		"add":          g.compileAdd,
+		"primary":      g.compilePrimary,
+		"index":        g.compileIndex,
+		"unique":       g.compileUnique,
+		"foreign":      g.compileForeign,
+		"dropColumn":   g.compileDropColumn,
+		"renameColumn": g.compileRenameColumn,
+		"dropIndex":    g.compileDropIndex,
+		"dropUnique":   g.compileDropIndex,
+		"dropForeign":  g.compileDropForeign,
+		"drop":         g.compileDrop,
+		"dropIfExists": g.compileDropIfExists,
+	}
+}
+
+func (g *MysqlGrammar) GetMysqlTypeComponents() map[string]interface{} {
+
+	return map[string]interface{}{
+		"string":     g.typeString,
+		"text":       g.typeText,
+		"integer":    g.typeInteger,
+		"bigInteger": g.typeBigInteger,
+		"boolean":    g.typeBoolean,
+		"decimal":    g.typeDecimal,
+		"json":       g.typeJson,
+		"timestamp":  g.typeTimestamp,
+	}
+}
+
+func (g *MysqlGrammar) GetMysqlModifierComponents() map[int]interface{} {
+
+	return map[int]interface{}{
+		0: g.modifyUnsigned,
+		1: g.modifyNullable,
+		2: g.modifyDefault,
+		3: g.modifyIncrement,
+		4: g.modifyComment,
+		5: g.modifyAfter,
+	}
+}
+
+func (g *MysqlGrammar) CompileTableExists() string {
+
+	return "select count(*) from information_schema.tables " +
+		"where table_schema = database() and table_name = ? and table_type = 'BASE TABLE'"
+}
+
+func (g *MysqlGrammar) CompileColumnExists() string {
+
+	return "select count(*) from information_schema.columns " +
+		"where table_schema = database() and table_name = ? and column_name = ?"
+}
+
+func (g *MysqlGrammar) compileCreate(blueprint *schema.Blueprint, command *schema.Command) string {
+
+	return fmt.Sprintf("create table %v (%v)",
+		g.Wrap(blueprint.GetTable()),
+		strings.Join(g.getColumns(blueprint), ", "),
+	)
+}
+
+func (g *MysqlGrammar) compileAdd(blueprint *schema.Blueprint, command *schema.Command) string {
+
+	columns := g.getColumns(blueprint)
+	for i, column := range columns {
+		columns[i] = "add " + column
+	}
+
+	return "alter table " + g.Wrap(blueprint.GetTable()) + " " + strings.Join(columns, ", ")
+}
+
+func (g *MysqlGrammar) compilePrimary(blueprint *schema.Blueprint, command *schema.Command) string {
+
+	return fmt.Sprintf("alter table %v add primary key (%v)",
+		g.Wrap(blueprint.GetTable()),
+		g.columnize(command.Columns),
+	)
+}
+
+func (g *MysqlGrammar) compileIndex(blueprint *schema.Blueprint, command *schema.Command) string {
+
+	return g.compileKey(blueprint, command, "index")
+}
+
+func (g *MysqlGrammar) compileUnique(blueprint *schema.Blueprint, command *schema.Command) string {
+
+	return g.compileKey(blueprint, command, "unique")
+}
+
+func (g *MysqlGrammar) compileKey(blueprint *schema.Blueprint, command *schema.Command, keyType string) string {
+
+	return fmt.Sprintf("alter table %v add %v %v(%v)",
+		g.Wrap(blueprint.GetTable()),
+		keyType,
+		g.Wrap(command.Index),
+		g.columnize(command.Columns),
+	)
+}
+
+func (g *MysqlGrammar) compileDropColumn(blueprint *schema.Blueprint, command *schema.Command) string {
+
+	columns := make([]string, 0, len(command.Columns))
+	for _, column := range command.Columns {
+		columns = append(columns, "drop "+g.Wrap(column))
+	}
+
+	return "alter table " + g.Wrap(blueprint.GetTable()) + " " + strings.Join(columns, ", ")
+}
+
+func (g *MysqlGrammar) compileDropIndex(blueprint *schema.Blueprint, command *schema.Command) string {
+
+	return "alter table " + g.Wrap(blueprint.GetTable()) + " drop index " + g.Wrap(command.Index)
+}
+
+func (g *MysqlGrammar) compileDropForeign(blueprint *schema.Blueprint, command *schema.Command) string {
+
+	return "alter table " + g.Wrap(blueprint.GetTable()) + " drop foreign key " + g.Wrap(command.Index)
+}
+
+func (g *MysqlGrammar) typeString(column *schema.ColumnDefinition) string {
+
+	return fmt.Sprintf("varchar(%d)", column.GetLength())
+}
+
+func (g *MysqlGrammar) typeText(column *schema.ColumnDefinition) string {
+
+	return "text"
+}
+
+func (g *MysqlGrammar) typeInteger(column *schema.ColumnDefinition) string {
+
+	return "int"
+}
+
+func (g *MysqlGrammar) typeBigInteger(column *schema.ColumnDefinition) string {
+
+	return "bigint"
+}
+
+func (g *MysqlGrammar) typeBoolean(column *schema.ColumnDefinition) string {
+
+	return "tinyint(1)"
+}
+
+func (g *MysqlGrammar) typeDecimal(column *schema.ColumnDefinition) string {
+
+	return fmt.Sprintf("decimal(%d, %d)", column.GetTotal(), column.GetPlaces())
+}
+
+func (g *MysqlGrammar) typeJson(column *schema.ColumnDefinition) string {
+
+	return "json"
+}
+
+func (g *MysqlGrammar) typeTimestamp(column *schema.ColumnDefinition) string {
+
+	return "timestamp"
+}
+
+func (g *MysqlGrammar) modifyUnsigned(blueprint *schema.Blueprint, column *schema.ColumnDefinition) string {
+
+	if column.IsUnsigned() {
+		return " unsigned"
+	}
+
+	return ""
+}
+
+func (g *MysqlGrammar) modifyNullable(blueprint *schema.Blueprint, column *schema.ColumnDefinition) string {
+
+	if column.IsNullable() {
+		return " null"
+	}
+
+	return " not null"
+}
+
+func (g *MysqlGrammar) modifyDefault(blueprint *schema.Blueprint, column *schema.ColumnDefinition) string {
+
+	if column.HasDefault() {
+		return " default " + g.getDefaultValue(column.GetDefault())
+	}
+
+	return ""
+}
+
+func (g *MysqlGrammar) modifyIncrement(blueprint *schema.Blueprint, column *schema.ColumnDefinition) string {
+
+	if column.IsAutoIncrement() {
+		return " auto_increment primary key"
+	}
+
+	return ""
+}
+
+func (g *MysqlGrammar) modifyComment(blueprint *schema.Blueprint, column *schema.ColumnDefinition) string {
+
+	if len(column.GetComment()) > 0 {
+		return " comment " + g.getDefaultValue(column.GetComment())
+	}
+
+	return ""
+}
+
+func (g *MysqlGrammar) modifyAfter(blueprint *schema.Blueprint, column *schema.ColumnDefinition) string {
+
+	if len(column.GetAfter()) > 0 {
+		return " after " + g.Wrap(column.GetAfter())
+	}
+
+	return ""
+}